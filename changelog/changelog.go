@@ -0,0 +1,207 @@
+// Package changelog builds human readable release notes from the differences
+// between two versions of an OBO Graph.
+package changelog
+
+import (
+	"sort"
+
+	"github.com/dictyBase/go-obograph/diff"
+	"github.com/dictyBase/go-obograph/graph"
+)
+
+// DefaultNamespace groups the terms that have no namespace of their own nor
+// a default one from the graph.
+const DefaultNamespace = "other"
+
+// Parent is a parent term along with the predicate relating it.
+type Parent struct {
+	Predicate graph.NodeID
+	Term      *diff.Term
+}
+
+// NewTerm is a term added in the new version.
+type NewTerm struct {
+	Term    *diff.Term
+	Parents []*Parent
+}
+
+// Obsoletion is a term obsoleted in the new version.
+type Obsoletion struct {
+	Term       *diff.Term
+	ReplacedBy []*diff.Term
+	Consider   []*diff.Term
+}
+
+// Rename is a term whose label changed.
+type Rename struct {
+	Term     *diff.Term
+	OldLabel string
+}
+
+// Move is a term whose parents changed.
+type Move struct {
+	Term       *diff.Term
+	OldParents []*Parent
+	NewParents []*Parent
+}
+
+// Section has the changes of the terms of a namespace.
+type Section struct {
+	Namespace   string
+	NewTerms    []*NewTerm
+	Obsoletions []*Obsoletion
+	Renames     []*Rename
+	Moves       []*Move
+}
+
+// Changelog has the changes between two versions of a graph grouped by
+// namespace.
+type Changelog struct {
+	OldVersion string
+	NewVersion string
+	// Sections are sorted by namespace
+	Sections []*Section
+}
+
+// Build computes the changelog between an old and a new version of a graph.
+func Build(old, cur graph.OboGraph) *Changelog {
+	rpt := diff.Compare(old, cur)
+	bld := &builder{
+		old:      old,
+		cur:      cur,
+		sections: make(map[string]*Section),
+	}
+	skip := make(map[graph.NodeID]bool)
+	for _, trm := range rpt.Added {
+		skip[trm.ID] = true
+		bld.section(trm).NewTerms = append(bld.section(trm).NewTerms, &NewTerm{
+			Term:    trm,
+			Parents: parents(cur, trm.ID),
+		})
+	}
+	for _, trm := range rpt.Obsoleted {
+		skip[trm.ID] = true
+		mta := cur.GetTerm(trm.ID).Meta()
+		bld.section(trm).Obsoletions = append(bld.section(trm).Obsoletions, &Obsoletion{
+			Term:       trm,
+			ReplacedBy: bld.references(mta.ReplacedBy()),
+			Consider:   bld.references(mta.Consider()),
+		})
+	}
+	for _, chg := range rpt.Changed {
+		// an obsoleted term, usually renamed to "obsolete ...", is reported
+		// as an obsoletion only
+		if skip[chg.Term.ID] {
+			continue
+		}
+		for _, fld := range chg.Fields {
+			if fld.Field == graph.FieldLabel {
+				bld.section(chg.Term).Renames = append(bld.section(chg.Term).Renames, &Rename{
+					Term:     chg.Term,
+					OldLabel: fld.Old,
+				})
+			}
+		}
+	}
+	moved := make(map[graph.NodeID]bool)
+	for _, rels := range [][]*diff.Relationship{rpt.AddedRelationships, rpt.RemovedRelationships} {
+		for _, rel := range rels {
+			idn := rel.Subject.ID
+			if skip[idn] || moved[idn] || !old.ExistsTerm(idn) || !cur.ExistsTerm(idn) {
+				continue
+			}
+			moved[idn] = true
+			trm := bld.term(idn)
+			bld.section(trm).Moves = append(bld.section(trm).Moves, &Move{
+				Term:       trm,
+				OldParents: parents(old, idn),
+				NewParents: parents(cur, idn),
+			})
+		}
+	}
+
+	return bld.changelog(rpt)
+}
+
+type builder struct {
+	old      graph.OboGraph
+	cur      graph.OboGraph
+	sections map[string]*Section
+}
+
+func (b *builder) section(trm *diff.Term) *Section {
+	nsp := trm.Namespace
+	if len(nsp) == 0 && b.cur.Meta() != nil {
+		nsp = b.cur.Meta().Namespace()
+	}
+	if len(nsp) == 0 {
+		nsp = DefaultNamespace
+	}
+	sct, ok := b.sections[nsp]
+	if !ok {
+		sct = &Section{Namespace: nsp}
+		b.sections[nsp] = sct
+	}
+
+	return sct
+}
+
+// term describes a term of the new version, or of the old version when it
+// was removed.
+func (b *builder) term(idn graph.NodeID) *diff.Term {
+	trm := b.cur.GetTerm(idn)
+	if trm == nil {
+		trm = b.old.GetTerm(idn)
+	}
+	if trm == nil {
+		return &diff.Term{ID: idn}
+	}
+
+	return newTerm(trm)
+}
+
+// references returns the terms referred by the values, which are either
+// IRIs or CURIEs.
+func (b *builder) references(vals []string) []*diff.Term {
+	trms := make([]*diff.Term, 0)
+	for _, val := range vals {
+		trms = append(trms, b.term(graph.NormalizeID(val)))
+	}
+
+	return trms
+}
+
+func (b *builder) changelog(rpt *diff.Report) *Changelog {
+	chl := &Changelog{
+		OldVersion: rpt.OldVersion,
+		NewVersion: rpt.NewVersion,
+		Sections:   make([]*Section, 0, len(b.sections)),
+	}
+	for _, sct := range b.sections {
+		chl.Sections = append(chl.Sections, sct)
+	}
+	sort.Slice(chl.Sections, func(i, j int) bool {
+		return chl.Sections[i].Namespace < chl.Sections[j].Namespace
+	})
+
+	return chl
+}
+
+func parents(grph graph.OboGraph, idn graph.NodeID) []*Parent {
+	prnt := make([]*Parent, 0)
+	grph.EachParent(idn, func(trm graph.Term, rel graph.Relationship) bool {
+		prnt = append(prnt, &Parent{Predicate: rel.Predicate(), Term: newTerm(trm)})
+
+		return true
+	})
+
+	return prnt
+}
+
+func newTerm(trm graph.Term) *diff.Term {
+	return &diff.Term{
+		ID:        trm.ID(),
+		Label:     trm.Label(),
+		Namespace: trm.Meta().Namespace(),
+	}
+}
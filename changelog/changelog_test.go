@@ -0,0 +1,137 @@
+package changelog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/diff"
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGraph(assert *require.Assertions) graph.OboGraph {
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(
+		filepath.Join(
+			filepath.Dir(dir), "testdata", "so.json",
+		),
+	)
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+
+	return grph
+}
+
+func releaseGraph(assert *require.Assertions) graph.OboGraph {
+	grph := buildGraph(assert)
+	gene := grph.GetTerm("SO_0000704")
+	assert.NoError(grph.UpdateTerm(graph.NewTermWithMeta(
+		gene.ID(), gene.Meta(), gene.RdfType(), "genetic locus", gene.IRI(),
+	)))
+	grph.AddTerm(graph.NewTerm(
+		"SO_9000001", "CLASS", "test gene", "http://purl.obolibrary.org/obo/SO_9000001",
+	))
+	assert.NoError(grph.AddRelationshipWithID("SO_0000704", "SO_9000001", "is_a"))
+	assert.NoError(grph.ObsoleteTerm("SO_0000340", "SO_0000704", "SO_0001217"))
+	chrom := grph.GetTerm("SO_0000340")
+	assert.NoError(grph.UpdateTerm(graph.NewTermWithMeta(
+		chrom.ID(), chrom.Meta(), chrom.RdfType(), "obsolete chromosome", chrom.IRI(),
+	)))
+	assert.NoError(grph.RemoveRelationship("SO_0000704", "SO_0001217", "is_a"))
+	assert.NoError(grph.AddRelationshipWithID("SO_0001411", "SO_0001217", "is_a"))
+
+	return grph
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	assert.Empty(Build(buildGraph(assert), buildGraph(assert)).Sections, "expect no changes")
+	chl := Build(buildGraph(assert), releaseGraph(assert))
+	assert.Len(chl.Sections, 1, "expect one namespace")
+	sct := chl.Sections[0]
+	assert.Equal("sequence", sct.Namespace)
+	assert.Len(sct.NewTerms, 1, "expect one new term")
+	assert.Equal(graph.NodeID("SO_9000001"), sct.NewTerms[0].Term.ID)
+	assert.Len(sct.NewTerms[0].Parents, 1, "expect one parent of the new term")
+	assert.Equal(graph.NodeID("SO_0000704"), sct.NewTerms[0].Parents[0].Term.ID)
+	assert.Len(sct.Obsoletions, 1, "expect one obsoleted term")
+	obs := sct.Obsoletions[0]
+	assert.Equal(graph.NodeID("SO_0000340"), obs.Term.ID)
+	assert.Len(obs.ReplacedBy, 1, "expect one replacement")
+	assert.Equal("genetic locus", obs.ReplacedBy[0].Label)
+	assert.Len(obs.Consider, 1, "expect one term to consider")
+	assert.Equal(graph.NodeID("SO_0001217"), obs.Consider[0].ID)
+	assert.Len(sct.Renames, 1, "expect one renamed term, not the obsoleted one")
+	assert.Equal("gene", sct.Renames[0].OldLabel)
+	assert.Equal("genetic locus", sct.Renames[0].Term.Label)
+	moved := make([]graph.NodeID, 0)
+	for _, mov := range sct.Moves {
+		moved = append(moved, mov.Term.ID)
+	}
+	assert.Contains(moved, graph.NodeID("SO_0001217"), "expect moved term")
+	assert.NotContains(moved, graph.NodeID("SO_9000001"), "expect new term not to be moved")
+	assert.NotContains(moved, graph.NodeID("SO_0000340"), "expect obsoleted term not to be moved")
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	chl := Build(buildGraph(assert), releaseGraph(assert))
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(chl.WriteMarkdown(buff), "expect no error from markdown")
+	mdn := buff.String()
+	assert.Contains(mdn, "## sequence")
+	assert.Contains(mdn, "### New terms (1)\n\n- SO_9000001 test gene, is_a SO_0000704 genetic locus\n")
+	assert.Contains(mdn, "- SO_0000340 obsolete chromosome, replaced by SO_0000704 genetic locus, consider SO_0001217")
+	assert.Contains(mdn, "- SO_0000704 renamed from gene to genetic locus\n")
+	assert.Contains(mdn, "### Moved terms")
+	buff.Reset()
+	assert.NoError(chl.WriteHTML(buff), "expect no error from html")
+	htm := buff.String()
+	assert.Contains(htm, "<h2>sequence</h2>")
+	assert.Contains(htm, "<li>SO_9000001 test gene, is_a SO_0000704 genetic locus</li>")
+	assert.Contains(htm, "<li>SO_0000704 renamed from gene to genetic locus</li>")
+	buff.Reset()
+	assert.NoError(Build(buildGraph(assert), buildGraph(assert)).WriteHTML(buff))
+	assert.Contains(buff.String(), "<p>No changes.</p>")
+	buff.Reset()
+	assert.NoError((&Changelog{}).WriteMarkdown(buff))
+	assert.Contains(buff.String(), "# Changes from unknown version to unknown version\n")
+	buff.Reset()
+	assert.NoError((&Changelog{}).WriteHTML(buff))
+	assert.Contains(buff.String(), "<h1>Changes from unknown version to unknown version</h1>")
+}
+
+func TestRenderEscape(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	chl := &Changelog{Sections: []*Section{{
+		Namespace: "my_namespace",
+		NewTerms: []*NewTerm{{
+			Term: &diff.Term{ID: "SO_9000001", Label: "*bold* a|b `code`"},
+			Parents: []*Parent{{
+				Predicate: "is_a",
+				Term:      &diff.Term{ID: "SO_0000704", Label: "gene_locus"},
+			}},
+		}},
+		Renames: []*Rename{{
+			Term:     &diff.Term{ID: "SO_0000704", Label: "gene_locus"},
+			OldLabel: "gene*",
+		}},
+	}}}
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(chl.WriteMarkdown(buff), "expect no error from markdown")
+	mdn := buff.String()
+	assert.Contains(mdn, "## my\\_namespace\n")
+	assert.Contains(mdn, "- SO_9000001 \\*bold\\* a\\|b \\`code\\`, is_a SO_0000704 gene\\_locus\n")
+	assert.Contains(mdn, "- SO_0000704 renamed from gene\\* to gene\\_locus\n")
+	buff.Reset()
+	assert.NoError(chl.WriteHTML(buff), "expect no error from html")
+	assert.Contains(buff.String(), "<li>SO_9000001 *bold* a|b `code`, is_a SO_0000704 gene_locus</li>")
+}
@@ -0,0 +1,187 @@
+package changelog
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/diff"
+)
+
+var htmlTmpl = template.Must(template.New("changelog").Funcs(template.FuncMap{
+	"term":    termText,
+	"parents": parentsText,
+	"terms":   termsText,
+	"version": diff.VersionLabel,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Changes from {{version .OldVersion}} to {{version .NewVersion}}</title>
+</head>
+<body>
+<h1>Changes from {{version .OldVersion}} to {{version .NewVersion}}</h1>
+{{- range .Sections}}
+<h2>{{.Namespace}}</h2>
+{{- if .NewTerms}}
+<h3>New terms ({{len .NewTerms}})</h3>
+<ul>
+{{- range .NewTerms}}
+<li>{{term .Term}}{{with parents .Parents}}, {{.}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Obsoletions}}
+<h3>Obsoleted terms ({{len .Obsoletions}})</h3>
+<ul>
+{{- range .Obsoletions}}
+<li>{{term .Term}}{{with terms .ReplacedBy}}, replaced by {{.}}{{end}}{{with terms .Consider}}, consider {{.}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Renames}}
+<h3>Renamed terms ({{len .Renames}})</h3>
+<ul>
+{{- range .Renames}}
+<li>{{.Term.ID}} renamed from {{.OldLabel}} to {{.Term.Label}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Moves}}
+<h3>Moved terms ({{len .Moves}})</h3>
+<ul>
+{{- range .Moves}}
+<li>{{term .Term}} moved from {{or (parents .OldParents) "the top"}} to {{or (parents .NewParents) "the top"}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- else}}
+<p>No changes.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteMarkdown writes the changelog as a Markdown document.
+func (c *Changelog) WriteMarkdown(w io.Writer) error {
+	var bld strings.Builder
+	fmt.Fprintf(
+		&bld, "# Changes from %s to %s\n",
+		diff.EscapeMarkdown(diff.VersionLabel(c.OldVersion)),
+		diff.EscapeMarkdown(diff.VersionLabel(c.NewVersion)),
+	)
+	for _, sct := range c.Sections {
+		fmt.Fprintf(&bld, "\n## %s\n", diff.EscapeMarkdown(sct.Namespace))
+		if len(sct.NewTerms) > 0 {
+			fmt.Fprintf(&bld, "\n### New terms (%d)\n\n", len(sct.NewTerms))
+			for _, ntrm := range sct.NewTerms {
+				fmt.Fprintf(&bld, "- %s", mdTermText(ntrm.Term))
+				if len(ntrm.Parents) > 0 {
+					fmt.Fprintf(&bld, ", %s", joinParents(ntrm.Parents, mdTermText))
+				}
+				bld.WriteString("\n")
+			}
+		}
+		if len(sct.Obsoletions) > 0 {
+			fmt.Fprintf(&bld, "\n### Obsoleted terms (%d)\n\n", len(sct.Obsoletions))
+			for _, obs := range sct.Obsoletions {
+				fmt.Fprintf(&bld, "- %s", mdTermText(obs.Term))
+				if len(obs.ReplacedBy) > 0 {
+					fmt.Fprintf(&bld, ", replaced by %s", joinTerms(obs.ReplacedBy, mdTermText))
+				}
+				if len(obs.Consider) > 0 {
+					fmt.Fprintf(&bld, ", consider %s", joinTerms(obs.Consider, mdTermText))
+				}
+				bld.WriteString("\n")
+			}
+		}
+		if len(sct.Renames) > 0 {
+			fmt.Fprintf(&bld, "\n### Renamed terms (%d)\n\n", len(sct.Renames))
+			for _, rnm := range sct.Renames {
+				fmt.Fprintf(
+					&bld, "- %s renamed from %s to %s\n",
+					rnm.Term.ID,
+					diff.EscapeMarkdown(rnm.OldLabel),
+					diff.EscapeMarkdown(rnm.Term.Label),
+				)
+			}
+		}
+		if len(sct.Moves) > 0 {
+			fmt.Fprintf(&bld, "\n### Moved terms (%d)\n\n", len(sct.Moves))
+			for _, mov := range sct.Moves {
+				fmt.Fprintf(
+					&bld, "- %s moved from %s to %s\n",
+					mdTermText(mov.Term),
+					orTop(joinParents(mov.OldParents, mdTermText)),
+					orTop(joinParents(mov.NewParents, mdTermText)),
+				)
+			}
+		}
+	}
+	if len(c.Sections) == 0 {
+		bld.WriteString("\nNo changes.\n")
+	}
+	if _, err := io.WriteString(w, bld.String()); err != nil {
+		return fmt.Errorf("error in writing markdown %s", err)
+	}
+
+	return nil
+}
+
+// WriteHTML writes the changelog as a HTML document.
+func (c *Changelog) WriteHTML(w io.Writer) error {
+	if err := htmlTmpl.Execute(w, c); err != nil {
+		return fmt.Errorf("error in writing html %s", err)
+	}
+
+	return nil
+}
+
+func termText(trm *diff.Term) string {
+	if len(trm.Label) == 0 {
+		return string(trm.ID)
+	}
+
+	return fmt.Sprintf("%s %s", trm.ID, trm.Label)
+}
+
+// mdTermText is termText with the label escaped for Markdown, the html
+// template escapes the text by itself.
+func mdTermText(trm *diff.Term) string {
+	return termText(&diff.Term{ID: trm.ID, Label: diff.EscapeMarkdown(trm.Label)})
+}
+
+func termsText(trms []*diff.Term) string {
+	return joinTerms(trms, termText)
+}
+
+func parentsText(prnt []*Parent) string {
+	return joinParents(prnt, termText)
+}
+
+func joinTerms(trms []*diff.Term, text func(*diff.Term) string) string {
+	txt := make([]string, 0, len(trms))
+	for _, trm := range trms {
+		txt = append(txt, text(trm))
+	}
+
+	return strings.Join(txt, ", ")
+}
+
+func joinParents(prnt []*Parent, text func(*diff.Term) string) string {
+	txt := make([]string, 0, len(prnt))
+	for _, p := range prnt {
+		txt = append(txt, fmt.Sprintf("%s %s", p.Predicate, text(p.Term)))
+	}
+
+	return strings.Join(txt, ", ")
+}
+
+func orTop(txt string) string {
+	if len(txt) == 0 {
+		return "the top"
+	}
+
+	return txt
+}
@@ -99,23 +99,36 @@ func LoadOntologies(clt *cli.Context) error {
 				exitCode,
 			)
 		}
-		if !dsa.ExistsOboGraph(grph) {
-			logger.Infof("obograph %s does not exist, have to be loaded", objs)
-			if err := saveNewGraph(dsa, grph, logger); err != nil {
+		grphs := []graph.OboGraph{grph}
+		if clt.Bool("split-namespace") {
+			var cross []graph.Relationship
+			grphs, cross = graph.SplitByNamespace(grph)
+			logger.Infof(
+				"split %s into %d namespaces leaving out %d relationships",
+				objs, len(grphs), len(cross),
+			)
+		}
+		for _, sgrph := range grphs {
+			if err := saveGraph(dsa, sgrph, logger); err != nil {
 				return cli.NewExitError(err.Error(), exitCode)
 			}
-
-			continue
-		}
-		logger.Infof("obograph %s exist, have to be updated", objs)
-		if err := saveExistentGraph(dsa, grph, logger); err != nil {
-			return cli.NewExitError(err.Error(), exitCode)
 		}
 	}
 
 	return nil
 }
 
+func saveGraph(dsa storage.DataSource, grph graph.OboGraph, logger *logrus.Entry) error {
+	if !dsa.ExistsOboGraph(grph) {
+		logger.Infof("obograph %s does not exist, have to be loaded", grph.ID())
+
+		return saveNewGraph(dsa, grph, logger)
+	}
+	logger.Infof("obograph %s exist, have to be updated", grph.ID())
+
+	return saveExistentGraph(dsa, grph, logger)
+}
+
 func getLogger(clt *cli.Context) *logrus.Entry {
 	log := logrus.New()
 	log.Out = os.Stderr
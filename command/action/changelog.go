@@ -0,0 +1,57 @@
+package action
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/dictyBase/go-obograph/changelog"
+	"github.com/urfave/cli"
+)
+
+// GenerateChangelog writes the changelog between an old and a new version of
+// an ontology in Markdown or HTML format.
+func GenerateChangelog(clt *cli.Context) error {
+	format := clt.String("format")
+	if format != "markdown" && format != "html" {
+		return cli.NewExitError(
+			fmt.Sprintf("unknown changelog format %s", format),
+			exitCode,
+		)
+	}
+	old, err := buildGraphFromFile(clt.String("old"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCode)
+	}
+	cur, err := buildGraphFromFile(clt.String("new"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCode)
+	}
+	out, err := os.Create(clt.String("output"))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in creating file %s %s", clt.String("output"), err),
+			exitCode,
+		)
+	}
+	defer out.Close()
+	bfw := bufio.NewWriter(out)
+	chl := changelog.Build(old, cur)
+	if format == "html" {
+		err = chl.WriteHTML(bfw)
+	} else {
+		err = chl.WriteMarkdown(bfw)
+	}
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCode)
+	}
+	if err := bfw.Flush(); err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in writing file %s %s", clt.String("output"), err),
+			exitCode,
+		)
+	}
+	getLogger(clt).Infof("wrote changelog for %d namespaces", len(chl.Sections))
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package action
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/urfave/cli"
+)
+
+var extractStrategies = map[string]graph.ExtractStrategy{
+	"ancestors":             graph.ExtractAncestors,
+	"ancestors-descendants": graph.ExtractAncestorsDescendants,
+	"mireot-bottom":         graph.ExtractMireotBottom,
+	"mireot-top":            graph.ExtractMireotTop,
+}
+
+// ExtractSubgraph extracts a subgraph from seed terms and writes it in
+// obograph JSON format.
+func ExtractSubgraph(clt *cli.Context) error {
+	strategy, ok := extractStrategies[clt.String("strategy")]
+	if !ok {
+		return cli.NewExitError(
+			fmt.Sprintf("unknown extract strategy %s", clt.String("strategy")),
+			exitCode,
+		)
+	}
+	grph, err := buildGraphFromFile(clt.String("obojson"))
+	if err != nil {
+		return cli.NewExitError(err.Error(), exitCode)
+	}
+	egrph, err := graph.Extract(grph, nodeIDs(clt.StringSlice("seed")), &graph.ExtractOptions{
+		Strategy:   strategy,
+		Predicates: nodeIDs(clt.StringSlice("predicate")),
+		UpperTerms: nodeIDs(clt.StringSlice("upper-term")),
+	})
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in extracting subgraph %s", err),
+			exitCode,
+		)
+	}
+	out, err := os.Create(clt.String("output"))
+	if err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in creating file %s %s", clt.String("output"), err),
+			exitCode,
+		)
+	}
+	defer out.Close()
+	bfw := bufio.NewWriter(out)
+	if err := graph.WriteJSON(bfw, egrph); err != nil {
+		return cli.NewExitError(err.Error(), exitCode)
+	}
+	if err := bfw.Flush(); err != nil {
+		return cli.NewExitError(
+			fmt.Sprintf("error in writing file %s %s", clt.String("output"), err),
+			exitCode,
+		)
+	}
+	getLogger(clt).Infof(
+		"extracted %d terms and %d relationships",
+		len(egrph.Terms()), len(egrph.Relationships()),
+	)
+
+	return nil
+}
+
+func nodeIDs(values []string) []graph.NodeID {
+	ids := make([]graph.NodeID, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, graph.NodeID(v))
+	}
+
+	return ids
+}
+
+func buildGraphFromFile(file string) (graph.OboGraph, error) {
+	rdr, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("error in opening file %s %s", file, err)
+	}
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	if err != nil {
+		return nil, fmt.Errorf("error in building graph from %s %s", file, err)
+	}
+
+	return grph, nil
+}
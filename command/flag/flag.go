@@ -62,7 +62,73 @@ func OntologyFlags() []cli.Flag {
 				Usage:    "input ontology files in obograph json format",
 				Required: true,
 			},
+			cli.BoolFlag{
+				Name:  "split-namespace",
+				Usage: "store every namespace of an ontology as a separate ontology",
+			},
 		},
 		arangoflag.ArangodbFlags()...,
 	)
 }
+
+// ExtractFlags returns a cli.flag slice to use in the command line arguments
+// of the subgraph extraction.
+func ExtractFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     "obojson,j",
+			Usage:    "input ontology file in obograph json format",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "output,o",
+			Usage:    "output file for the extracted subgraph in obograph json format",
+			Required: true,
+		},
+		cli.StringSliceFlag{
+			Name:     "seed,s",
+			Usage:    "id of a seed term, for example SO_0000704",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "strategy",
+			Usage: "extraction strategy, one of ancestors, ancestors-descendants, mireot-bottom or mireot-top",
+			Value: "ancestors",
+		},
+		cli.StringSliceFlag{
+			Name:  "predicate,p",
+			Usage: "id of a predicate to limit the extraction, for example is_a",
+		},
+		cli.StringSliceFlag{
+			Name:  "upper-term,u",
+			Usage: "id of an upper term that bounds the mireot-bottom strategy",
+		},
+	}
+}
+
+// ChangelogFlags returns a cli.flag slice to use in the command line
+// arguments of the changelog generator.
+func ChangelogFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     "old",
+			Usage:    "old version of the ontology in obograph json format",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "new",
+			Usage:    "new version of the ontology in obograph json format",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:     "output,o",
+			Usage:    "output file for the changelog",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  "format,f",
+			Usage: "format of the changelog, either markdown or html",
+			Value: "markdown",
+		},
+	}
+}
@@ -0,0 +1,272 @@
+// Package diff compares two versions of an OBO Graph and reports the changes
+// of their terms and relationships.
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// Term identifies a term in a report.
+type Term struct {
+	ID        graph.NodeID `json:"id"`
+	Label     string       `json:"label"`
+	Namespace string       `json:"namespace,omitempty"`
+}
+
+// FieldChange is the change of a single field of a term. The single valued
+// fields, label and definition, have the old and new values while the multi
+// valued fields have the added and removed values.
+type FieldChange struct {
+	Field   string   `json:"field"`
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// TermChange is a term whose fields changed between the versions.
+type TermChange struct {
+	*Term
+	Fields []*FieldChange `json:"fields"`
+}
+
+// Relationship is a relationship in a report.
+type Relationship struct {
+	Object    *Term        `json:"object"`
+	Subject   *Term        `json:"subject"`
+	Predicate graph.NodeID `json:"predicate"`
+}
+
+// Report is the difference between an old and a new version of a graph.
+type Report struct {
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
+	// Added are the terms of the new version only
+	Added []*Term `json:"added"`
+	// Removed are the terms of the old version only
+	Removed []*Term `json:"removed"`
+	// Obsoleted are the terms that are deprecated in the new version only
+	Obsoleted []*Term `json:"obsoleted"`
+	// Changed are the terms with changed fields, an obsoleted term is listed
+	// here too when it has other changes
+	Changed []*TermChange `json:"changed"`
+	// AddedRelationships are the relationships of the new version only
+	AddedRelationships []*Relationship `json:"added_relationships"`
+	// RemovedRelationships are the relationships of the old version only
+	RemovedRelationships []*Relationship `json:"removed_relationships"`
+}
+
+// IsEmpty tells whether the versions have no difference.
+func (r *Report) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 &&
+		len(r.Obsoleted) == 0 && len(r.Changed) == 0 &&
+		len(r.AddedRelationships) == 0 && len(r.RemovedRelationships) == 0
+}
+
+// Compare reports the differences between an old and a new version of a
+// graph. The terms and relationships of the new version are listed in its
+// order, the removed ones in the order of the old version.
+func Compare(old, cur graph.OboGraph) *Report {
+	rpt := &Report{
+		OldVersion: graph.GraphVersion(old),
+		NewVersion: graph.GraphVersion(cur),
+		Added:      make([]*Term, 0),
+		Removed:    make([]*Term, 0),
+		Obsoleted:  make([]*Term, 0),
+		Changed:    make([]*TermChange, 0),
+	}
+	cur.EachTerm(func(trm graph.Term) bool {
+		otrm := old.GetTerm(trm.ID())
+		if otrm == nil {
+			rpt.Added = append(rpt.Added, newTerm(trm))
+
+			return true
+		}
+		obsoleted := trm.IsDeprecated() && !otrm.IsDeprecated()
+		if obsoleted {
+			rpt.Obsoleted = append(rpt.Obsoleted, newTerm(trm))
+		}
+		if fields := fieldChanges(otrm, trm, obsoleted); len(fields) > 0 {
+			rpt.Changed = append(rpt.Changed, &TermChange{
+				Term:   newTerm(trm),
+				Fields: fields,
+			})
+		}
+
+		return true
+	})
+	old.EachTerm(func(trm graph.Term) bool {
+		if !cur.ExistsTerm(trm.ID()) {
+			rpt.Removed = append(rpt.Removed, newTerm(trm))
+		}
+
+		return true
+	})
+	rpt.AddedRelationships = relationshipChanges(cur, old)
+	rpt.RemovedRelationships = relationshipChanges(old, cur)
+
+	return rpt
+}
+
+// relationshipChanges returns the relationships of the first graph that are
+// not in the second one.
+func relationshipChanges(grph, other graph.OboGraph) []*Relationship {
+	rels := make([]*Relationship, 0)
+	grph.EachRelationship(func(rel graph.Relationship) bool {
+		orel := other.GetRelationship(rel.Object(), rel.Subject())
+		if orel != nil && orel.Predicate() == rel.Predicate() {
+			return true
+		}
+		rels = append(rels, &Relationship{
+			Object:    newTerm(grph.GetTerm(rel.Object())),
+			Subject:   newTerm(grph.GetTerm(rel.Subject())),
+			Predicate: rel.Predicate(),
+		})
+
+		return true
+	})
+
+	return rels
+}
+
+// fieldChanges details the fields of a term that changed, as found by
+// graph.TermChanges, so that a diff and a merge agree on what changed. The
+// deprecation of an obsoleted term is left out, it is reported by Obsoleted.
+func fieldChanges(old, cur graph.Term, obsoleted bool) []*FieldChange {
+	fields := make([]*FieldChange, 0)
+	oldOpt, curOpt := old.Meta().Options(), cur.Meta().Options()
+	for _, name := range graph.TermChanges(old, cur) {
+		switch name {
+		case graph.FieldDeprecation:
+			if obsoleted {
+				continue
+			}
+			fields = append(fields, &FieldChange{
+				Field: name,
+				Old:   strconv.FormatBool(oldOpt.Deprecated),
+				New:   strconv.FormatBool(curOpt.Deprecated),
+			})
+		case graph.FieldLabel:
+			fields = append(fields, &FieldChange{Field: name, Old: old.Label(), New: cur.Label()})
+		case graph.FieldType:
+			fields = append(fields, &FieldChange{Field: name, Old: old.RdfType(), New: cur.RdfType()})
+		case graph.FieldDefinition:
+			fields = append(fields, &FieldChange{
+				Field: name,
+				Old:   definition(oldOpt),
+				New:   definition(curOpt),
+			})
+		default:
+			ovals, nvals := fieldValues(name, oldOpt), fieldValues(name, curOpt)
+			fld := &FieldChange{
+				Field:   name,
+				Added:   difference(nvals, ovals),
+				Removed: difference(ovals, nvals),
+			}
+			// only the order of the values changed
+			if len(fld.Added) == 0 && len(fld.Removed) == 0 {
+				fld.Old, fld.New = strings.Join(ovals, ", "), strings.Join(nvals, ", ")
+			}
+			fields = append(fields, fld)
+		}
+	}
+
+	return fields
+}
+
+func fieldValues(name string, opt *model.MetaOptions) []string {
+	switch name {
+	case graph.FieldSynonyms:
+		return synonyms(opt)
+	case graph.FieldXrefs:
+		return xrefs(opt)
+	case graph.FieldComments:
+		return opt.Comments
+	case graph.FieldSubsets:
+		return opt.Subsets
+	case graph.FieldProperties:
+		return properties(opt)
+	}
+
+	return nil
+}
+
+// difference returns the values of the first slice that are not in the
+// second one.
+func difference(vals, other []string) []string {
+	seen := make(map[string]bool)
+	for _, v := range other {
+		seen[v] = true
+	}
+	var diff []string
+	for _, v := range vals {
+		if !seen[v] {
+			diff = append(diff, v)
+		}
+	}
+
+	return diff
+}
+
+func definition(opt *model.MetaOptions) string {
+	if opt.Definition == nil {
+		return ""
+	}
+
+	return withXrefs(opt.Definition.Value(), opt.Definition.Xrefs())
+}
+
+func synonyms(opt *model.MetaOptions) []string {
+	vals := make([]string, 0, len(opt.Synonyms))
+	for _, syn := range opt.Synonyms {
+		vals = append(vals, withXrefs(fmt.Sprintf("%s %s", syn.Value(), syn.Scope()), syn.Xrefs()))
+	}
+
+	return vals
+}
+
+func properties(opt *model.MetaOptions) []string {
+	vals := make([]string, 0, len(opt.BaseProps))
+	for _, prop := range opt.BaseProps {
+		val := fmt.Sprintf("%s %s", prop.Pred(), prop.Value())
+		if len(prop.Lang()) > 0 {
+			val += "@" + prop.Lang()
+		}
+		if len(prop.ValType()) > 0 {
+			val += "^^" + prop.ValType()
+		}
+		vals = append(vals, val)
+	}
+
+	return vals
+}
+
+func withXrefs(val string, refs []string) string {
+	if len(refs) == 0 {
+		return val
+	}
+
+	return fmt.Sprintf("%s [%s]", val, strings.Join(refs, ", "))
+}
+
+func xrefs(opt *model.MetaOptions) []string {
+	vals := make([]string, 0, len(opt.Xrefs))
+	for _, x := range opt.Xrefs {
+		vals = append(vals, x.Value())
+	}
+
+	return vals
+}
+
+func newTerm(trm graph.Term) *Term {
+	return &Term{
+		ID:        trm.ID(),
+		Label:     trm.Label(),
+		Namespace: trm.Meta().Namespace(),
+	}
+}
@@ -0,0 +1,150 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGraph(assert *require.Assertions) graph.OboGraph {
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(
+		filepath.Join(
+			filepath.Dir(dir), "testdata", "so.json",
+		),
+	)
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+
+	return grph
+}
+
+func changedGraph(assert *require.Assertions) (graph.OboGraph, graph.NodeID) {
+	grph := buildGraph(assert)
+	gene := grph.GetTerm("SO_0000704")
+	opt := gene.Meta().Options()
+	opt.Synonyms = append(opt.Synonyms, model.NewSynonym("hasExactSynonym", "gene locus"))
+	assert.NoError(grph.UpdateTerm(graph.NewTermWithMeta(
+		gene.ID(), model.NewMeta(opt), gene.RdfType(), "genetic locus", gene.IRI(),
+	)))
+	grph.AddTerm(graph.NewTerm(
+		"SO_9000001", "CLASS", "test gene", "http://purl.obolibrary.org/obo/SO_9000001",
+	))
+	assert.NoError(grph.AddRelationshipWithID(gene.ID(), "SO_9000001", "is_a"))
+	leaf := graph.Leaves(grph)[0].ID()
+	assert.NoError(grph.RemoveTerm(leaf, true))
+	assert.NoError(grph.ObsoleteTerm("SO_0000340", "SO_0000704"))
+
+	return grph, leaf
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	old := buildGraph(assert)
+	assert.True(Compare(old, buildGraph(assert)).IsEmpty(), "expect no changes")
+	cur, leaf := changedGraph(assert)
+	rpt := Compare(old, cur)
+	assert.Len(rpt.Added, 1, "expect one added term")
+	assert.Equal(graph.NodeID("SO_9000001"), rpt.Added[0].ID)
+	assert.Len(rpt.Removed, 1, "expect one removed term")
+	assert.Equal(leaf, rpt.Removed[0].ID)
+	assert.Len(rpt.Obsoleted, 1, "expect one obsoleted term")
+	assert.Equal(graph.NodeID("SO_0000340"), rpt.Obsoleted[0].ID)
+	assert.Equal("sequence", rpt.Obsoleted[0].Namespace, "expect namespace")
+	assert.Len(rpt.Changed, 2, "expect the changed and the obsoleted terms")
+	changed := make(map[graph.NodeID]*TermChange)
+	for _, tch := range rpt.Changed {
+		changed[tch.ID] = tch
+	}
+	obs := changed["SO_0000340"]
+	assert.NotNil(obs, "expect properties of the obsoleted term to change")
+	assert.Len(obs.Fields, 1, "expect no deprecation change for an obsoleted term")
+	assert.Equal(graph.FieldProperties, obs.Fields[0].Field)
+	assert.Contains(obs.Fields[0].Added, model.ReplacedByIRI+" http://purl.obolibrary.org/obo/SO_0000704")
+	chg := changed["SO_0000704"]
+	assert.NotNil(chg, "expect gene to change")
+	for idn, tch := range changed {
+		names := make([]string, 0, len(tch.Fields))
+		for _, fld := range tch.Fields {
+			names = append(names, fld.Field)
+		}
+		fields := make([]string, 0)
+		for _, name := range graph.TermChanges(old.GetTerm(idn), cur.GetTerm(idn)) {
+			if name != graph.FieldDeprecation || idn != "SO_0000340" {
+				fields = append(fields, name)
+			}
+		}
+		assert.Equalf(fields, names, "expect the fields of graph.TermChanges for %s", idn)
+	}
+	assert.Len(chg.Fields, 2, "expect label and synonym changes")
+	assert.Equal(graph.FieldLabel, chg.Fields[0].Field)
+	assert.Equal("gene", chg.Fields[0].Old)
+	assert.Equal("genetic locus", chg.Fields[0].New)
+	assert.Equal(graph.FieldSynonyms, chg.Fields[1].Field)
+	assert.Equal([]string{"gene locus EXACT"}, chg.Fields[1].Added)
+	assert.Empty(chg.Fields[1].Removed, "expect no removed synonym")
+	assert.Len(rpt.AddedRelationships, 1, "expect one added relationship")
+	assert.Equal(graph.NodeID("SO_9000001"), rpt.AddedRelationships[0].Subject.ID)
+	assert.Equal(graph.NodeID("is_a"), rpt.AddedRelationships[0].Predicate)
+	assert.Len(
+		rpt.RemovedRelationships,
+		len(old.Parents("SO_0000340"))+len(old.Children("SO_0000340"))+len(old.Parents(leaf)),
+		"expect relationships of the removed and obsoleted terms",
+	)
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	cur, _ := changedGraph(assert)
+	rpt := Compare(buildGraph(assert), cur)
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(rpt.WriteMarkdown(buff), "expect no error from markdown")
+	mdn := buff.String()
+	assert.Contains(mdn, "## Added terms (1)")
+	assert.Contains(mdn, "- `SO_9000001` test gene")
+	assert.Contains(mdn, "### `SO_0000704` genetic locus")
+	assert.Contains(mdn, "- label: \"gene\" → \"genetic locus\"")
+	assert.Contains(mdn, "- synonyms: added \"gene locus EXACT\"")
+	assert.Contains(mdn, "- `SO_9000001` test gene *is\\_a* `SO_0000704` genetic locus")
+	buff.Reset()
+	assert.NoError(rpt.WriteJSON(buff), "expect no error from json")
+	jrpt := &Report{}
+	assert.NoError(json.Unmarshal(buff.Bytes(), jrpt), "expect valid json")
+	assert.Equal(rpt, jrpt, "expect same report from json")
+	buff.Reset()
+	assert.NoError(Compare(cur, cur).WriteMarkdown(buff))
+	assert.Contains(buff.String(), "No changes.")
+}
+
+func TestRenderEscape(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rpt := &Report{
+		Added: []*Term{{ID: "SO_9000001", Label: "*bold* _it_ a|b `code`"}},
+		Changed: []*TermChange{{
+			Term: &Term{ID: "SO_0000704", Label: "gene"},
+			Fields: []*FieldChange{{
+				Field: graph.FieldDefinition,
+				Old:   "a | b",
+				New:   "a_b*",
+			}},
+		}},
+	}
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(rpt.WriteMarkdown(buff), "expect no error from markdown")
+	mdn := buff.String()
+	assert.Contains(mdn, "- `SO_9000001` \\*bold\\* \\_it\\_ a\\|b \\`code\\`")
+	assert.Contains(mdn, "- definition: \"a \\| b\" → \"a\\_b\\*\"")
+	assert.Equal(`a\\b`, EscapeMarkdown(`a\b`), "expect escaped backslash")
+}
@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"|", `\|`,
+	"`", "\\`",
+)
+
+// WriteJSON writes the report in JSON format.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("error in encoding report %s", err)
+	}
+
+	return nil
+}
+
+// WriteMarkdown writes the report as a Markdown document.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var bld strings.Builder
+	fmt.Fprintf(
+		&bld, "# Changes from %s to %s\n",
+		EscapeMarkdown(VersionLabel(r.OldVersion)),
+		EscapeMarkdown(VersionLabel(r.NewVersion)),
+	)
+	writeTerms(&bld, "Added terms", r.Added)
+	writeTerms(&bld, "Removed terms", r.Removed)
+	writeTerms(&bld, "Obsoleted terms", r.Obsoleted)
+	if len(r.Changed) > 0 {
+		fmt.Fprintf(&bld, "\n## Changed terms (%d)\n", len(r.Changed))
+		for _, chg := range r.Changed {
+			fmt.Fprintf(&bld, "\n### %s\n\n", termText(chg.Term))
+			for _, fld := range chg.Fields {
+				bld.WriteString(fieldText(fld))
+			}
+		}
+	}
+	writeRelationships(&bld, "Added relationships", r.AddedRelationships)
+	writeRelationships(&bld, "Removed relationships", r.RemovedRelationships)
+	if r.IsEmpty() {
+		bld.WriteString("\nNo changes.\n")
+	}
+	if _, err := io.WriteString(w, bld.String()); err != nil {
+		return fmt.Errorf("error in writing markdown %s", err)
+	}
+
+	return nil
+}
+
+func writeTerms(bld *strings.Builder, title string, trms []*Term) {
+	if len(trms) == 0 {
+		return
+	}
+	fmt.Fprintf(bld, "\n## %s (%d)\n\n", title, len(trms))
+	for _, trm := range trms {
+		fmt.Fprintf(bld, "- %s\n", termText(trm))
+	}
+}
+
+func writeRelationships(bld *strings.Builder, title string, rels []*Relationship) {
+	if len(rels) == 0 {
+		return
+	}
+	fmt.Fprintf(bld, "\n## %s (%d)\n\n", title, len(rels))
+	for _, rel := range rels {
+		fmt.Fprintf(
+			bld, "- %s *%s* %s\n",
+			termText(rel.Subject), EscapeMarkdown(string(rel.Predicate)), termText(rel.Object),
+		)
+	}
+}
+
+func fieldText(fld *FieldChange) string {
+	if len(fld.Added) == 0 && len(fld.Removed) == 0 {
+		return fmt.Sprintf("- %s: %s → %s\n", fld.Field, quote(fld.Old), quote(fld.New))
+	}
+	parts := make([]string, 0, 2)
+	if len(fld.Added) > 0 {
+		parts = append(parts, "added "+quoteAll(fld.Added))
+	}
+	if len(fld.Removed) > 0 {
+		parts = append(parts, "removed "+quoteAll(fld.Removed))
+	}
+
+	return fmt.Sprintf("- %s: %s\n", fld.Field, strings.Join(parts, "; "))
+}
+
+func termText(trm *Term) string {
+	if len(trm.Label) == 0 {
+		return fmt.Sprintf("`%s`", trm.ID)
+	}
+
+	return fmt.Sprintf("`%s` %s", trm.ID, EscapeMarkdown(trm.Label))
+}
+
+func quote(val string) string {
+	if len(val) == 0 {
+		return "_none_"
+	}
+
+	return fmt.Sprintf("\"%s\"", EscapeMarkdown(val))
+}
+
+func quoteAll(vals []string) string {
+	quoted := make([]string, 0, len(vals))
+	for _, v := range vals {
+		quoted = append(quoted, quote(v))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// VersionLabel returns the version to show in a report, "unknown version"
+// when it is empty.
+func VersionLabel(version string) string {
+	if len(version) == 0 {
+		return "unknown version"
+	}
+
+	return version
+}
+
+// EscapeMarkdown escapes the characters of a text that Markdown would read as
+// emphasis, code or a table cell.
+func EscapeMarkdown(txt string) string {
+	return mdEscaper.Replace(txt)
+}
@@ -0,0 +1,626 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// compactGraph is a read optimised OboGraph. The term ids are interned into
+// dense integers, in the order of the terms, and the relationships are kept
+// as sorted integer triples with compressed sparse row(CSR) adjacency arrays
+// in both directions. The added relationships are appended unsorted and the
+// arrays are sorted and rebuilt lazily, on the first read after the
+// addition, so loading a graph costs a single rebuild. Removing terms or
+// relationships rebuilds them right away.
+type compactGraph struct {
+	meta  *model.Meta
+	id    string
+	lbl   string
+	iri   string
+	index map[NodeID]int32
+	terms []Term
+	// relationships as term indices, sorted by parent(object) and then by
+	// children(subject), followed by the pending ones
+	objs  []int32
+	subjs []int32
+	preds []int32
+	// the relationships of term i are at down[downOff[i]:downOff[i+1]] as
+	// children and at up[upOff[i]:upOff[i+1]] as parent
+	downOff []int32
+	down    []int32
+	upOff   []int32
+	up      []int32
+	// pending are the relationships added since the last rebuild, keyed by
+	// parent(object) and children(subject)
+	pending map[[2]int32]int32
+	// dirty is set when there are pending relationships
+	dirty int32
+	mu    sync.Mutex
+}
+
+func newCompactGraph(meta *model.Meta, idn, lbl, iri string) OboGraph {
+	return &compactGraph{
+		meta:    meta,
+		id:      idn,
+		lbl:     lbl,
+		iri:     iri,
+		index:   make(map[NodeID]int32),
+		downOff: []int32{0},
+		upOff:   []int32{0},
+		pending: make(map[[2]int32]int32),
+	}
+}
+
+// NewCompactGraph builds a read optimised copy of a graph that keeps the
+// order of its terms and relationships. It uses a fraction of the memory of
+// the graph built by BuildGraph and is faster to traverse, but removing terms
+// or relationships is linear to the size of the graph, so it is best suited
+// for graphs that are built once and mostly read.
+func NewCompactGraph(grph OboGraph) OboGraph {
+	cgr := newCompactGraph(grph.Meta(), grph.ID(), grph.Label(), grph.IRI()).(*compactGraph)
+	grph.EachTerm(func(trm Term) bool {
+		cgr.AddTerm(trm)
+
+		return true
+	})
+	grph.EachRelationship(func(rel Relationship) bool {
+		cgr.addEdge(rel.Object(), rel.Subject(), rel.Predicate())
+
+		return true
+	})
+
+	return cgr
+}
+
+// BuildCompactGraph builds a read optimised in memory graph from
+// JSON-encoded obograph reader, the same as BuildGraph with CompactLayout.
+func BuildCompactGraph(r io.Reader) (OboGraph, error) {
+	return BuildGraph(r, CompactLayout)
+}
+
+// Label is a short human readable description of the graph.
+func (c *compactGraph) Label() string {
+	return c.lbl
+}
+
+// ID is a short and unique name of the graph.
+func (c *compactGraph) ID() string {
+	return c.id
+}
+
+// IRI represents a stable URL for locating the source OWL formatted file.
+func (c *compactGraph) IRI() string {
+	return c.iri
+}
+
+// Meta returns the associated Meta container.
+func (c *compactGraph) Meta() *model.Meta {
+	return c.meta
+}
+
+// ExistsTerm checks for existence of a term.
+func (c *compactGraph) ExistsTerm(id NodeID) bool {
+	_, ok := c.index[id]
+
+	return ok
+}
+
+// GetTerm fetches an existing term.
+func (c *compactGraph) GetTerm(id NodeID) Term {
+	if idx, ok := c.index[id]; ok {
+		return c.terms[idx]
+	}
+
+	return nil
+}
+
+// GetRelationship fetches relationship(edge) between parent(object) and
+// children(subject).
+func (c *compactGraph) GetRelationship(obj, subj NodeID) (rel Relationship) {
+	c.ensure()
+	if edx := c.findEdge(obj, subj); edx >= 0 {
+		return c.relationship(int32(edx))
+	}
+
+	return rel
+}
+
+// Relationships returns all relationships(edges) in the graph.
+func (c *compactGraph) Relationships() []Relationship {
+	var rel []Relationship
+	c.EachRelationship(func(r Relationship) bool {
+		rel = append(rel, r)
+
+		return true
+	})
+
+	return rel
+}
+
+// Terms returns all terms(node/vertex) in the graph.
+func (c *compactGraph) Terms() []Term {
+	trm := make([]Term, len(c.terms))
+	copy(trm, c.terms)
+
+	return trm
+}
+
+// TermsByType provides a filtered list of specific terms.
+func (c *compactGraph) TermsByType(rtype string) []Term {
+	trm := make([]Term, 0)
+	for _, t := range c.terms {
+		if t.RdfType() == rtype {
+			trm = append(trm, t)
+		}
+	}
+
+	return trm
+}
+
+// Children returns all children terms(depth one).
+func (c *compactGraph) Children(id NodeID) []Term {
+	c.ensure()
+	trm := make([]Term, 0)
+	if idx, ok := c.index[id]; ok {
+		for _, edx := range c.down[c.downOff[idx]:c.downOff[idx+1]] {
+			trm = append(trm, c.terms[c.subjs[edx]])
+		}
+	}
+
+	return trm
+}
+
+// Parents returns all parent terms(depth one).
+func (c *compactGraph) Parents(id NodeID) []Term {
+	c.ensure()
+	trm := make([]Term, 0)
+	if idx, ok := c.index[id]; ok {
+		for _, edx := range c.up[c.upOff[idx]:c.upOff[idx+1]] {
+			trm = append(trm, c.terms[c.objs[edx]])
+		}
+	}
+
+	return trm
+}
+
+// Ancestors returns all reachable(direct or indirect) parent terms. It uses
+// BFS algorithm.
+func (c *compactGraph) Ancestors(idn NodeID) []Term {
+	c.ensure()
+	var atrm []Term
+	start, ok := c.index[idn]
+	if !ok {
+		return atrm
+	}
+	visited := newBitset(len(c.terms))
+	visited.set(start)
+	qid := []int32{start}
+	for len(qid) > 0 {
+		nid := qid[len(qid)-1]
+		qid = qid[:len(qid)-1]
+		for _, edx := range c.up[c.upOff[nid]:c.upOff[nid+1]] {
+			if parent := c.objs[edx]; !visited.has(parent) {
+				visited.set(parent)
+				qid = append(qid, parent)
+				atrm = append(atrm, c.terms[parent])
+			}
+		}
+	}
+
+	return atrm
+}
+
+// Descendents returns all reachable(direct or indirect) children terms. It uses
+// BFS algorithm.
+func (c *compactGraph) Descendents(idn NodeID) []Term {
+	c.ensure()
+	drm := make([]Term, 0)
+	start, ok := c.index[idn]
+	if !ok {
+		return drm
+	}
+	visited := newBitset(len(c.terms))
+	visited.set(start)
+	qid := []int32{start}
+	for len(qid) > 0 {
+		nid := qid[0]
+		qid = qid[1:]
+		for _, edx := range c.down[c.downOff[nid]:c.downOff[nid+1]] {
+			if child := c.subjs[edx]; !visited.has(child) {
+				visited.set(child)
+				qid = append(qid, child)
+				drm = append(drm, c.terms[child])
+			}
+		}
+	}
+
+	return drm
+}
+
+// DescendentsDFS returns all reachable(direct or indirect) children terms
+// using DFS algorithm.
+func (c *compactGraph) DescendentsDFS(idn NodeID) []Term {
+	c.ensure()
+	drm := make([]Term, 0)
+	start, ok := c.index[idn]
+	if !ok {
+		return drm
+	}
+	visited := newBitset(len(c.terms))
+	stn := []int32{start}
+	for len(stn) > 0 {
+		nid := stn[len(stn)-1]
+		stn = stn[:len(stn)-1]
+		visited.set(nid)
+		for _, edx := range c.down[c.downOff[nid]:c.downOff[nid+1]] {
+			if child := c.subjs[edx]; !visited.has(child) {
+				drm = append(drm, c.terms[child])
+				stn = append(stn, child)
+			}
+		}
+	}
+
+	return drm
+}
+
+// EachTerm calls the function for every term, in the same order as Terms,
+// until it returns false.
+func (c *compactGraph) EachTerm(fn func(Term) bool) {
+	for _, t := range c.terms {
+		if !fn(t) {
+			return
+		}
+	}
+}
+
+// EachRelationship calls the function for every relationship, in the same
+// order as Relationships, until it returns false.
+func (c *compactGraph) EachRelationship(fn func(Relationship) bool) {
+	c.ensure()
+	for _, edx := range c.down {
+		if !fn(c.relationship(edx)) {
+			return
+		}
+	}
+}
+
+// EachChild calls the function for every children term(depth one) along with
+// the relationship to it until it returns false.
+func (c *compactGraph) EachChild(id NodeID, fn func(Term, Relationship) bool) {
+	c.ensure()
+	idx, ok := c.index[id]
+	if !ok {
+		return
+	}
+	for _, edx := range c.down[c.downOff[idx]:c.downOff[idx+1]] {
+		if !fn(c.terms[c.subjs[edx]], c.relationship(edx)) {
+			return
+		}
+	}
+}
+
+// EachParent calls the function for every parent term(depth one) along with
+// the relationship to it until it returns false.
+func (c *compactGraph) EachParent(id NodeID, fn func(Term, Relationship) bool) {
+	c.ensure()
+	idx, ok := c.index[id]
+	if !ok {
+		return
+	}
+	for _, edx := range c.up[c.upOff[idx]:c.upOff[idx+1]] {
+		if !fn(c.terms[c.objs[edx]], c.relationship(edx)) {
+			return
+		}
+	}
+}
+
+// AddTerm add a new Term to the graph overwriting any existing one, the
+// relationships of the existing term are removed.
+func (c *compactGraph) AddTerm(t Term) {
+	if idx, ok := c.index[t.ID()]; ok {
+		if c.countTermEdges(idx) > 0 {
+			c.removeEdges(func(edx int) bool {
+				return c.objs[edx] == idx || c.subjs[edx] == idx || c.preds[edx] == idx
+			})
+			c.rebuild()
+		}
+		c.terms[idx] = t
+
+		return
+	}
+	c.setTerm(t)
+}
+
+// UpdateTerm replaces an existing term keeping its relationships.
+func (c *compactGraph) UpdateTerm(t Term) error {
+	idx, ok := c.index[t.ID()]
+	if !ok {
+		return fmt.Errorf("node id %s does not exist", t.ID())
+	}
+	c.terms[idx] = t
+
+	return nil
+}
+
+// setTerm adds or overwrites a term, an overwritten term keeps its position
+// and its relationships.
+func (c *compactGraph) setTerm(t Term) {
+	if idx, ok := c.index[t.ID()]; ok {
+		c.terms[idx] = t
+
+		return
+	}
+	c.index[t.ID()] = int32(len(c.terms))
+	c.terms = append(c.terms, t)
+	// the new term has no relationship yet
+	c.downOff = append(c.downOff, c.downOff[len(c.downOff)-1])
+	c.upOff = append(c.upOff, c.upOff[len(c.upOff)-1])
+}
+
+// AddRelationship creates relationship between terms, it overrides the
+// existing terms and relationship.
+func (c *compactGraph) AddRelationship(obj, subj, pred Term) error {
+	c.setTerm(obj)
+	c.setTerm(subj)
+	c.setTerm(pred)
+	c.addEdge(obj.ID(), subj.ID(), pred.ID())
+
+	return nil
+}
+
+// AddRelationshipWithID creates relationship between existing terms.
+func (c *compactGraph) AddRelationshipWithID(obj, subj, pred NodeID) error {
+	if _, ok := c.index[obj]; !ok {
+		return fmt.Errorf("object node id %s does not exist", obj)
+	}
+	if _, ok := c.index[subj]; !ok {
+		return fmt.Errorf("subject node id %s does not exist", subj)
+	}
+	if _, ok := c.index[pred]; !ok {
+		return fmt.Errorf("predicate node id %s does not exist", pred)
+	}
+	c.addEdge(obj, subj, pred)
+
+	return nil
+}
+
+// RemoveTerm removes a term from the graph. With cascade, all the
+// relationships where the term is the parent, the children or the predicate
+// are removed too, otherwise an error is returned if there is any such
+// relationship.
+func (c *compactGraph) RemoveTerm(idn NodeID, cascade bool) error {
+	c.ensure()
+	idx, ok := c.index[idn]
+	if !ok {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+	if n := c.countTermEdges(idx); n > 0 && !cascade {
+		return fmt.Errorf(
+			"node id %s is used in %d relationships",
+			idn, n,
+		)
+	}
+	c.removeEdges(func(edx int) bool {
+		return c.objs[edx] == idx || c.subjs[edx] == idx || c.preds[edx] == idx
+	})
+	// shift down the indices of the terms that come after the removed one
+	c.terms = append(c.terms[:idx], c.terms[idx+1:]...)
+	delete(c.index, idn)
+	for i := int(idx); i < len(c.terms); i++ {
+		c.index[c.terms[i].ID()] = int32(i)
+	}
+	for _, ids := range [][]int32{c.objs, c.subjs, c.preds} {
+		for i, v := range ids {
+			if v > idx {
+				ids[i] = v - 1
+			}
+		}
+	}
+	c.rebuild()
+
+	return nil
+}
+
+// RemoveRelationship removes the relationship between parent(object) and
+// children(subject) with the given predicate.
+func (c *compactGraph) RemoveRelationship(obj, subj, pred NodeID) error {
+	c.ensure()
+	edx := c.findEdge(obj, subj)
+	if edx < 0 || c.terms[c.preds[edx]].ID() != pred {
+		return fmt.Errorf(
+			"relationship %s between %s and %s does not exist",
+			pred, obj, subj,
+		)
+	}
+	c.removeEdges(func(i int) bool { return i == edx })
+	c.rebuild()
+
+	return nil
+}
+
+// ObsoleteTerm marks a term as deprecated, removes all of its relationships
+// and records the replacement and the terms to consider instead of it. An
+// empty replacement is not recorded.
+func (c *compactGraph) ObsoleteTerm(idn, replacedBy NodeID, consider ...NodeID) error {
+	c.ensure()
+	idx, ok := c.index[idn]
+	if !ok {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+	for edx, pred := range c.preds {
+		if pred == idx {
+			return fmt.Errorf(
+				"node id %s is the predicate of relationship between %s and %s",
+				idn, c.terms[c.objs[edx]].ID(), c.terms[c.subjs[edx]].ID(),
+			)
+		}
+	}
+	c.removeEdges(func(edx int) bool {
+		return c.objs[edx] == idx || c.subjs[edx] == idx
+	})
+	c.rebuild()
+	c.terms[idx] = obsoleteTerm(c, c.terms[idx], replacedBy, consider)
+
+	return nil
+}
+
+func (c *compactGraph) relationship(edx int32) Relationship {
+	return NewRelationship(
+		c.terms[c.objs[edx]].ID(),
+		c.terms[c.subjs[edx]].ID(),
+		c.terms[c.preds[edx]].ID(),
+	)
+}
+
+// findEdge returns the index of the relationship between two terms or -1,
+// looking at both the rebuilt and the pending relationships.
+func (c *compactGraph) findEdge(obj, subj NodeID) int {
+	oidx, ok := c.index[obj]
+	if !ok {
+		return -1
+	}
+	sidx, ok := c.index[subj]
+	if !ok {
+		return -1
+	}
+	if edx, ok := c.pending[[2]int32{oidx, sidx}]; ok {
+		return int(edx)
+	}
+	for _, edx := range c.down[c.downOff[oidx]:c.downOff[oidx+1]] {
+		if c.subjs[edx] == sidx {
+			return int(edx)
+		}
+	}
+
+	return -1
+}
+
+func (c *compactGraph) addEdge(obj, subj, pred NodeID) {
+	if edx := c.findEdge(obj, subj); edx >= 0 {
+		// overwritten relationship keeps its position
+		c.preds[edx] = c.index[pred]
+
+		return
+	}
+	oidx, sidx := c.index[obj], c.index[subj]
+	c.pending[[2]int32{oidx, sidx}] = int32(len(c.objs))
+	c.objs = append(c.objs, oidx)
+	c.subjs = append(c.subjs, sidx)
+	c.preds = append(c.preds, c.index[pred])
+	atomic.StoreInt32(&c.dirty, 1)
+}
+
+func (c *compactGraph) countTermEdges(idx int32) int {
+	count := 0
+	for edx := range c.objs {
+		if c.objs[edx] == idx || c.subjs[edx] == idx || c.preds[edx] == idx {
+			count++
+		}
+	}
+
+	return count
+}
+
+// removeEdges removes the matching relationships keeping the order of the
+// rest, the adjacency arrays have to be rebuilt afterwards.
+func (c *compactGraph) removeEdges(match func(int) bool) {
+	kept := 0
+	for edx := range c.objs {
+		if match(edx) {
+			continue
+		}
+		c.objs[kept] = c.objs[edx]
+		c.subjs[kept] = c.subjs[edx]
+		c.preds[kept] = c.preds[edx]
+		kept++
+	}
+	c.objs = c.objs[:kept]
+	c.subjs = c.subjs[:kept]
+	c.preds = c.preds[:kept]
+}
+
+// ensure rebuilds the adjacency arrays when relationships were added since
+// the last rebuild. Concurrent readers wait for a single rebuild.
+func (c *compactGraph) ensure() {
+	if atomic.LoadInt32(&c.dirty) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if atomic.LoadInt32(&c.dirty) == 1 {
+		c.rebuild()
+	}
+}
+
+func (c *compactGraph) rebuild() {
+	sort.Sort(edgeSorter{c})
+	c.downOff, c.down = buildCSR(len(c.terms), c.objs)
+	c.upOff, c.up = buildCSR(len(c.terms), c.subjs)
+	c.pending = make(map[[2]int32]int32)
+	atomic.StoreInt32(&c.dirty, 0)
+}
+
+// bitset marks the visited terms of a traversal by their indices.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(idx int32) {
+	b[idx/64] |= 1 << (uint(idx) % 64)
+}
+
+func (b bitset) has(idx int32) bool {
+	return b[idx/64]&(1<<(uint(idx)%64)) != 0
+}
+
+// edgeSorter sorts the relationships by parent(object) and then by
+// children(subject).
+type edgeSorter struct {
+	*compactGraph
+}
+
+func (e edgeSorter) Len() int {
+	return len(e.objs)
+}
+
+func (e edgeSorter) Less(i, j int) bool {
+	if e.objs[i] != e.objs[j] {
+		return e.objs[i] < e.objs[j]
+	}
+
+	return e.subjs[i] < e.subjs[j]
+}
+
+func (e edgeSorter) Swap(i, j int) {
+	e.objs[i], e.objs[j] = e.objs[j], e.objs[i]
+	e.subjs[i], e.subjs[j] = e.subjs[j], e.subjs[i]
+	e.preds[i], e.preds[j] = e.preds[j], e.preds[i]
+}
+
+// buildCSR groups the relationship indices by term, keeping the order of
+// the relationships within every term.
+func buildCSR(size int, ends []int32) ([]int32, []int32) {
+	off := make([]int32, size+1)
+	for _, idx := range ends {
+		off[idx+1]++
+	}
+	for i := 0; i < size; i++ {
+		off[i+1] += off[i]
+	}
+	pos := make([]int32, size)
+	copy(pos, off[:size])
+	adj := make([]int32, len(ends))
+	for edx, idx := range ends {
+		adj[pos[idx]] = int32(edx)
+		pos[idx]++
+	}
+
+	return off, adj
+}
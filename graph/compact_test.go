@@ -0,0 +1,213 @@
+package graph
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func relTriples(rels []Relationship) [][3]NodeID {
+	trp := make([][3]NodeID, 0, len(rels))
+	for _, rel := range rels {
+		trp = append(trp, [3]NodeID{rel.Object(), rel.Subject(), rel.Predicate()})
+	}
+
+	return trp
+}
+
+func assertSameGraph(assert *require.Assertions, expected, actual OboGraph) {
+	assert.Equal(expected.ID(), actual.ID(), "expect same graph id")
+	assert.Equal(expected.IRI(), actual.IRI(), "expect same graph IRI")
+	assert.Equal(termPipe(expected.Terms()), termPipe(actual.Terms()), "expect same terms")
+	assert.Equal(
+		termPipe(expected.TermsByType("PROPERTY")),
+		termPipe(actual.TermsByType("PROPERTY")),
+		"expect same property terms",
+	)
+	assert.Equal(
+		relTriples(expected.Relationships()),
+		relTriples(actual.Relationships()),
+		"expect same relationships",
+	)
+	for _, trm := range expected.Terms() {
+		id := trm.ID()
+		assert.Equalf(termPipe(expected.Children(id)), termPipe(actual.Children(id)), "children of %s", id)
+		assert.Equalf(termPipe(expected.Parents(id)), termPipe(actual.Parents(id)), "parents of %s", id)
+		assert.Equalf(termPipe(expected.Ancestors(id)), termPipe(actual.Ancestors(id)), "ancestors of %s", id)
+	}
+}
+
+func TestCompactGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	crdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	cgrph, err := BuildGraph(crdr, CompactLayout)
+	assert.NoError(err, "expect no error from building the compact graph")
+	assert.IsType(&compactGraph{}, cgrph, "expect the compact layout")
+	assert.IsType(&graph{}, grph, "expect the map layout by default")
+	assertSameGraph(assert, grph, cgrph)
+	for _, id := range []NodeID{"SO_0000110", "SO_0000704", "SO_0001217", "SO_9999999"} {
+		assert.Equal(termPipe(grph.Descendents(id)), termPipe(cgrph.Descendents(id)))
+		assert.Equal(termPipe(grph.DescendentsDFS(id)), termPipe(cgrph.DescendentsDFS(id)))
+	}
+	rel := cgrph.GetRelationship("SO_0000010", "SO_0001217")
+	assert.Equal(NodeID("has_quality"), rel.Predicate(), "expect has_quality relationship")
+	assert.Nil(cgrph.GetRelationship("SO_0001217", "SO_0000010"), "expect no reverse relationship")
+	assert.Nil(cgrph.GetTerm("SO_9999999"), "expect no term")
+	assert.Equal(grph.GetTerm("SO_0000340"), cgrph.GetTerm("SO_0000340"), "expect same term")
+}
+
+func TestNewCompactGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	assertSameGraph(assert, grph, NewCompactGraph(grph))
+}
+
+func TestBuildCompactGraphError(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	cgrph, err := BuildCompactGraph(bytes.NewBufferString("{"))
+	assert.Error(err, "expect error from invalid json")
+	cgrph.AddTerm(NewTerm("TEST_1", "CLASS", "test1", "test1"))
+	cgrph.AddTerm(NewTerm("TEST_2", "CLASS", "test2", "test2"))
+	assert.NoError(cgrph.AddRelationshipWithID("TEST_1", "TEST_2", "TEST_1"))
+	assert.Equal([]NodeID{"TEST_2"}, termPipe(cgrph.Children("TEST_1")), "expect usable empty graph")
+	grph, err := BuildGraph(bytes.NewBufferString("{"))
+	assert.Error(err, "expect error from invalid json")
+	grph.AddTerm(NewTerm("TEST_1", "CLASS", "test1", "test1"))
+	assert.True(grph.ExistsTerm("TEST_1"), "expect usable empty graph")
+}
+
+func TestCompactGraphMutation(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	cgrph := NewCompactGraph(grph)
+	for _, gph := range []OboGraph{grph, cgrph} {
+		gph.AddTerm(NewTerm("TEST_1", "CLASS", "test1", "test1"))
+		assert.NoError(gph.AddRelationshipWithID("SO_0001217", "TEST_1", "is_a"))
+		assert.NoError(gph.AddRelationship(
+			gph.GetTerm("TEST_1"),
+			NewTerm("TEST_2", "CLASS", "test2", "test2"),
+			gph.GetTerm("part_of"),
+		))
+		assert.NoError(gph.AddRelationshipWithID("SO_0001217", "TEST_1", "part_of"))
+		assert.Error(gph.AddRelationshipWithID("SO_0001217", "TEST_3", "is_a"))
+		assert.Error(gph.RemoveTerm("SO_0000704", false))
+		assert.NoError(gph.RemoveTerm("SO_0000704", true))
+		assert.Error(gph.RemoveRelationship("SO_0000010", "SO_0001217", "is_a"))
+		assert.NoError(gph.RemoveRelationship("SO_0000010", "SO_0001217", "has_quality"))
+		assert.NoError(gph.ObsoleteTerm("SO_0000336", "SO_0001217", "TEST_1"))
+		assert.Error(gph.ObsoleteTerm("part_of", ""))
+		assert.NoError(gph.UpdateTerm(NewTerm("TEST_1", "CLASS", "renamed", "test1")))
+		assert.NotNil(gph.GetRelationship("TEST_1", "TEST_2"), "expect updated term to keep relationships")
+		gph.AddTerm(NewTerm("TEST_2", "CLASS", "test2", "test2"))
+		assert.Nil(gph.GetRelationship("TEST_1", "TEST_2"), "expect replaced term to lose relationships")
+	}
+	assertSameGraph(assert, grph, cgrph)
+	assert.True(cgrph.GetTerm("SO_0000336").IsDeprecated(), "expect term to be deprecated")
+	assert.Equal(
+		grph.GetTerm("SO_0000336").Meta().BasicPropertyValues(),
+		cgrph.GetTerm("SO_0000336").Meta().BasicPropertyValues(),
+		"expect same obsolete properties",
+	)
+}
+
+func soBytes(b *testing.B) []byte {
+	b.Helper()
+	rdr, err := getReader()
+	if err != nil {
+		b.Fatalf("error in getting reader %s", err)
+	}
+	if closer, ok := rdr.(io.Closer); ok {
+		defer closer.Close()
+	}
+	content, err := io.ReadAll(rdr)
+	if err != nil {
+		b.Fatalf("error in reading file %s", err)
+	}
+
+	return content
+}
+
+func heapAlloc() uint64 {
+	var mst runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&mst)
+
+	return mst.HeapAlloc
+}
+
+func BenchmarkGraphMemory(b *testing.B) {
+	content := soBytes(b)
+	for name, layout := range map[string]Layout{
+		"map":     MapLayout,
+		"compact": CompactLayout,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			var retained uint64
+			for i := 0; i < b.N; i++ {
+				before := heapAlloc()
+				grph, err := BuildGraph(bytes.NewReader(content), layout)
+				if err != nil {
+					b.Fatalf("error in building graph %s", err)
+				}
+				retained += heapAlloc() - before
+				runtime.KeepAlive(grph)
+			}
+			b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+		})
+	}
+}
+
+func BenchmarkGraphTraversal(b *testing.B) {
+	content := soBytes(b)
+	grph, err := BuildGraph(bytes.NewReader(content))
+	if err != nil {
+		b.Fatalf("error in building graph %s", err)
+	}
+	terms := grph.Terms()
+	for name, gph := range map[string]OboGraph{
+		"map":     grph,
+		"compact": NewCompactGraph(grph),
+	} {
+		b.Run(name+"/Descendents", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = gph.Descendents("SO_0000110")
+			}
+		})
+		b.Run(name+"/Ancestors", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, trm := range terms {
+					_ = gph.Ancestors(trm.ID())
+				}
+			}
+		})
+		b.Run(name+"/Children", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, trm := range terms {
+					_ = gph.Children(trm.ID())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cycle is a closed path of relationships, followed from child(subject) to
+// parent(object).
+type Cycle struct {
+	// Terms are the ids of the terms in the path, the first one is the
+	// parent of the last one
+	Terms []NodeID
+	// Predicates are the predicates of the relationships in the path,
+	// Predicates[i] connects Terms[i] to the next term
+	Predicates []NodeID
+	// Component are the ids of all terms that are mutually reachable with the
+	// terms of the path
+	Component []NodeID
+}
+
+// String renders the cycle as a path of terms and predicates.
+func (c *Cycle) String() string {
+	var bld strings.Builder
+	for i, id := range c.Terms {
+		fmt.Fprintf(&bld, "%s -%s-> ", id, c.Predicates[i])
+	}
+	if len(c.Terms) > 0 {
+		bld.WriteString(string(c.Terms[0]))
+	}
+
+	return bld.String()
+}
+
+// CycleError is returned when an operation requires an acyclic graph.
+type CycleError struct {
+	Cycles []*Cycle
+}
+
+func (e *CycleError) Error() string {
+	paths := make([]string, 0, len(e.Cycles))
+	for _, cyc := range e.Cycles {
+		paths = append(paths, cyc.String())
+	}
+
+	return fmt.Sprintf(
+		"graph has %d cycle(s): %s",
+		len(e.Cycles), strings.Join(paths, "; "),
+	)
+}
+
+// DetectCycles reports the cycles in the graph. For every group of mutually
+// reachable terms, one cycle going through its first term is reported along
+// with all the members of the group. When predicates are given, only
+// relationships with those predicates are followed.
+func DetectCycles(grph OboGraph, preds ...NodeID) []*Cycle {
+	trj := newTarjan(grph, preds)
+	for _, trm := range grph.Terms() {
+		if _, ok := trj.indices[trm.ID()]; !ok {
+			trj.connect(trm.ID())
+		}
+	}
+	cycles := make([]*Cycle, 0)
+	for _, comp := range trj.comps {
+		if len(comp) == 1 && !hasSelfLoop(grph, comp[0], preds) {
+			continue
+		}
+		cycles = append(cycles, cycleIn(grph, comp, preds))
+	}
+
+	return cycles
+}
+
+// TopologicalSort orders the terms of the graph so that every parent
+// (object) comes before its children(subjects). When predicates are given,
+// only relationships with those predicates are considered. A *CycleError is
+// returned if the relationships contain any cycle.
+func TopologicalSort(grph OboGraph, preds ...NodeID) ([]Term, error) {
+	terms := grph.Terms()
+	sorted := make([]Term, 0, len(terms))
+	indegree := make(map[NodeID]int)
+	qid := make([]NodeID, 0)
+	for _, trm := range terms {
+		indegree[trm.ID()] = len(ParentsByPredicate(grph, trm.ID(), preds...))
+		if indegree[trm.ID()] == 0 {
+			qid = append(qid, trm.ID())
+		}
+	}
+	for len(qid) > 0 {
+		nid := qid[0]
+		qid = qid[1:]
+		sorted = append(sorted, grph.GetTerm(nid))
+		for _, child := range ChildrenByPredicate(grph, nid, preds...) {
+			indegree[child.ID()]--
+			if indegree[child.ID()] == 0 {
+				qid = append(qid, child.ID())
+			}
+		}
+	}
+	if len(sorted) != len(terms) {
+		return sorted, &CycleError{Cycles: DetectCycles(grph, preds...)}
+	}
+
+	return sorted, nil
+}
+
+// tarjan finds the strongly connected components of the graph using
+// Tarjan's algorithm.
+type tarjan struct {
+	grph    OboGraph
+	preds   []NodeID
+	index   int
+	indices map[NodeID]int
+	lowlink map[NodeID]int
+	onStack map[NodeID]bool
+	stack   []NodeID
+	comps   [][]NodeID
+}
+
+func newTarjan(grph OboGraph, preds []NodeID) *tarjan {
+	return &tarjan{
+		grph:    grph,
+		preds:   preds,
+		indices: make(map[NodeID]int),
+		lowlink: make(map[NodeID]int),
+		onStack: make(map[NodeID]bool),
+	}
+}
+
+// connect visits the term and its ancestors, a component is added only
+// after all the components of its ancestors.
+func (t *tarjan) connect(idn NodeID) {
+	t.indices[idn] = t.index
+	t.lowlink[idn] = t.index
+	t.index++
+	t.stack = append(t.stack, idn)
+	t.onStack[idn] = true
+	for _, parent := range ParentsByPredicate(t.grph, idn, t.preds...) {
+		pid := parent.ID()
+		if _, ok := t.indices[pid]; !ok {
+			t.connect(pid)
+			if t.lowlink[pid] < t.lowlink[idn] {
+				t.lowlink[idn] = t.lowlink[pid]
+			}
+		} else if t.onStack[pid] && t.indices[pid] < t.lowlink[idn] {
+			t.lowlink[idn] = t.indices[pid]
+		}
+	}
+	if t.lowlink[idn] != t.indices[idn] {
+		return
+	}
+	comp := make([]NodeID, 0)
+	for {
+		nid := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		t.onStack[nid] = false
+		comp = append(comp, nid)
+		if nid == idn {
+			break
+		}
+	}
+	t.comps = append(t.comps, comp)
+}
+
+func hasSelfLoop(grph OboGraph, idn NodeID, preds []NodeID) bool {
+	rel := grph.GetRelationship(idn, idn)
+
+	return rel != nil && hasPredicate(rel.Predicate(), preds)
+}
+
+// cycleIn finds the shortest cycle through the first term of a strongly
+// connected component.
+func cycleIn(grph OboGraph, comp []NodeID, preds []NodeID) *Cycle {
+	members := make(map[NodeID]bool)
+	for _, id := range comp {
+		members[id] = true
+	}
+	start := comp[0]
+	prev := make(map[NodeID]NodeID)
+	qid := []NodeID{start}
+	last := start
+	for len(qid) > 0 && len(prev[start]) == 0 {
+		nid := qid[0]
+		qid = qid[1:]
+		for _, parent := range ParentsByPredicate(grph, nid, preds...) {
+			pid := parent.ID()
+			if _, ok := prev[pid]; ok || !members[pid] {
+				continue
+			}
+			prev[pid] = nid
+			if pid == start {
+				last = nid
+
+				break
+			}
+			qid = append(qid, pid)
+		}
+	}
+	// walk back from the last term to the start
+	path := []NodeID{last}
+	for nid := last; nid != start; {
+		nid = prev[nid]
+		path = append(path, nid)
+	}
+	cyc := &Cycle{Component: comp}
+	for i := len(path) - 1; i >= 0; i-- {
+		cyc.Terms = append(cyc.Terms, path[i])
+	}
+	for i, id := range cyc.Terms {
+		next := cyc.Terms[(i+1)%len(cyc.Terms)]
+		cyc.Predicates = append(
+			cyc.Predicates,
+			grph.GetRelationship(next, id).Predicate(),
+		)
+	}
+
+	return cyc
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func cyclicGraph(assert *require.Assertions) OboGraph {
+	grph := newOboGraph(model.NewMeta(&model.MetaOptions{}), "test", "", "test")
+	isa := buildIsaTerm()
+	partOf := NewTerm("part_of", "PROPERTY", "part_of", "part_of")
+	grph.AddTerm(isa)
+	grph.AddTerm(partOf)
+	for _, id := range []NodeID{"A", "B", "C", "D", "E"} {
+		grph.AddTerm(NewTerm(id, "CLASS", string(id), string(id)))
+	}
+	for _, rel := range [][]NodeID{
+		{"A", "B", "is_a"},
+		{"B", "C", "is_a"},
+		{"C", "A", "part_of"},
+		{"A", "D", "is_a"},
+		{"E", "E", "is_a"},
+	} {
+		assert.NoError(grph.AddRelationshipWithID(rel[0], rel[1], rel[2]))
+	}
+
+	return grph
+}
+
+func TestDetectCycles(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := cyclicGraph(assert)
+	cycles := DetectCycles(grph)
+	assert.Len(cycles, 2, "expect two cycles")
+	for _, cyc := range cycles {
+		assert.Len(cyc.Predicates, len(cyc.Terms), "expect a predicate for every term")
+		switch len(cyc.Terms) {
+		case 1:
+			assert.Equal([]NodeID{"E"}, cyc.Terms, "expect self loop of E")
+			assert.Equal([]NodeID{"is_a"}, cyc.Predicates)
+		case 3:
+			assert.ElementsMatch([]NodeID{"A", "B", "C"}, cyc.Terms)
+			assert.ElementsMatch([]NodeID{"is_a", "is_a", "part_of"}, cyc.Predicates)
+			assert.ElementsMatch([]NodeID{"A", "B", "C"}, cyc.Component)
+		default:
+			assert.Failf("unexpected cycle", "cycle %s", cyc)
+		}
+	}
+	isaCycles := DetectCycles(grph, NodeID("is_a"))
+	assert.Len(isaCycles, 1, "expect only the self loop through is_a")
+}
+
+func TestTopologicalSort(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := cyclicGraph(assert)
+	_, err := TopologicalSort(grph)
+	var cerr *CycleError
+	assert.True(errors.As(err, &cerr), "expect a cycle error")
+	assert.Len(cerr.Cycles, 2, "expect two cycles in the error")
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	sog, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sorted, err := TopologicalSort(sog, NodeID("is_a"))
+	assert.NoError(err, "expect is_a relationships to be acyclic")
+	assert.Len(sorted, len(sog.Terms()), "expect all terms to be sorted")
+	pos := make(map[NodeID]int)
+	for i, trm := range sorted {
+		pos[trm.ID()] = i
+	}
+	for _, rel := range sog.Relationships() {
+		if rel.Predicate() != "is_a" {
+			continue
+		}
+		assert.Lessf(
+			pos[rel.Object()], pos[rel.Subject()],
+			"expect %s before %s", rel.Object(), rel.Subject(),
+		)
+	}
+	assert.Empty(DetectCycles(sog, NodeID("is_a")), "expect no is_a cycle")
+}
@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// ExtractStrategy decides which terms are extracted along with the seed
+// terms.
+type ExtractStrategy int
+
+const (
+	// ExtractAncestors extracts the seed terms and all of their ancestors.
+	ExtractAncestors ExtractStrategy = iota
+	// ExtractAncestorsDescendants extracts the seed terms along with all of
+	// their ancestors and descendants.
+	ExtractAncestorsDescendants
+	// ExtractMireotBottom extracts the seed terms and their ancestors up to
+	// the upper terms, the MIREOT way of importing terms from the bottom.
+	ExtractMireotBottom
+	// ExtractMireotTop extracts the seed terms and all of their descendants,
+	// the MIREOT way of importing a branch from the top.
+	ExtractMireotTop
+)
+
+// ExtractOptions configures the extraction of a subgraph.
+type ExtractOptions struct {
+	// Strategy decides the terms to extract, ExtractAncestors by default
+	Strategy ExtractStrategy
+	// Predicates limits the traversal and the extracted relationships to the
+	// given predicates, all predicates are used when empty
+	Predicates []NodeID
+	// UpperTerms bounds the ancestors of the ExtractMireotBottom strategy,
+	// the upper terms are extracted but not their ancestors
+	UpperTerms []NodeID
+}
+
+// Extract builds a new self-contained graph from the seed terms. The new
+// graph has the terms selected by the strategy, the relationships between
+// them, the property terms used by those relationships and the meta of the
+// original graph. The terms and relationships are shared with the original
+// graph.
+func Extract(grph OboGraph, seeds []NodeID, opt *ExtractOptions) (OboGraph, error) {
+	if opt == nil {
+		opt = &ExtractOptions{}
+	}
+	for _, idn := range seeds {
+		if !grph.ExistsTerm(idn) {
+			return &graph{}, fmt.Errorf("node id %s does not exist", idn)
+		}
+	}
+	selected, err := extractTerms(grph, seeds, opt)
+	if err != nil {
+		return &graph{}, err
+	}
+	rels := make([]Relationship, 0)
+	used := make(map[NodeID]bool)
+	grph.EachRelationship(func(rel Relationship) bool {
+		if selected[rel.Object()] && selected[rel.Subject()] &&
+			hasPredicate(rel.Predicate(), opt.Predicates) {
+			rels = append(rels, rel)
+			used[rel.Predicate()] = true
+		}
+
+		return true
+	})
+	egrph := newOboGraph(
+		model.NewMeta(grph.Meta().Options()),
+		grph.ID(),
+		grph.Label(),
+		grph.IRI(),
+	)
+	grph.EachTerm(func(trm Term) bool {
+		if selected[trm.ID()] || used[trm.ID()] || syntheticTerms[trm.ID()] {
+			egrph.AddTerm(trm)
+		}
+
+		return true
+	})
+	for _, rel := range rels {
+		err := egrph.AddRelationshipWithID(
+			rel.Object(),
+			rel.Subject(),
+			rel.Predicate(),
+		)
+		if err != nil {
+			return &graph{}, fmt.Errorf("error in adding relationship %s", err)
+		}
+	}
+
+	return egrph, nil
+}
+
+func extractTerms(
+	grph OboGraph,
+	seeds []NodeID,
+	opt *ExtractOptions,
+) (map[NodeID]bool, error) {
+	selected := make(map[NodeID]bool)
+	collect := func(trm []Term) {
+		for _, t := range trm {
+			selected[t.ID()] = true
+		}
+	}
+	for _, idn := range seeds {
+		selected[idn] = true
+		switch opt.Strategy {
+		case ExtractAncestors:
+			collect(AncestorsByPredicate(grph, idn, opt.Predicates...))
+		case ExtractAncestorsDescendants:
+			collect(AncestorsByPredicate(grph, idn, opt.Predicates...))
+			collect(DescendentsByPredicate(grph, idn, opt.Predicates...))
+		case ExtractMireotBottom:
+			if hasID(opt.UpperTerms, idn) {
+				continue
+			}
+			WalkAncestors(grph, idn, func(trm Term, _ int) WalkAction {
+				selected[trm.ID()] = true
+				if hasID(opt.UpperTerms, trm.ID()) {
+					return Prune
+				}
+
+				return Continue
+			}, opt.Predicates...)
+		case ExtractMireotTop:
+			collect(DescendentsByPredicate(grph, idn, opt.Predicates...))
+		default:
+			return selected, fmt.Errorf("unknown extract strategy %d", opt.Strategy)
+		}
+	}
+
+	return selected, nil
+}
+
+func hasID(ids []NodeID, idn NodeID) bool {
+	for _, id := range ids {
+		if id == idn {
+			return true
+		}
+	}
+
+	return false
+}
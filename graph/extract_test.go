@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	seed := NodeID("SO_0000704")
+
+	agrph, err := Extract(grph, []NodeID{seed}, nil)
+	assert.NoError(err, "expect no error from extracting ancestors")
+	assert.ElementsMatch(
+		append(termPipe(grph.Ancestors(seed)), seed),
+		termPipe(agrph.TermsByType("CLASS")),
+		"expect the seed and its ancestors",
+	)
+	assert.Equal(termPipe(grph.Ancestors(seed)), termPipe(agrph.Ancestors(seed)))
+	assert.Empty(agrph.Children(seed), "expect no children of the seed")
+	for _, rel := range agrph.Relationships() {
+		assert.True(agrph.ExistsTerm(rel.Predicate()), "expect predicate term")
+	}
+	assert.True(agrph.ExistsTerm("is_a"), "expect is_a term")
+	assert.Equal(grph.Meta().Version(), agrph.Meta().Version(), "expect graph meta")
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(WriteJSON(buff, agrph), "expect no error from writing json")
+	rgrph, err := BuildGraph(buff)
+	assert.NoError(err, "expect extracted graph to be self-contained")
+	assertSameGraph(assert, agrph, rgrph)
+
+	adgrph, err := Extract(grph, []NodeID{seed}, &ExtractOptions{
+		Strategy: ExtractAncestorsDescendants,
+	})
+	assert.NoError(err, "expect no error from extracting ancestors and descendants")
+	assert.Equal(termPipe(grph.Descendents(seed)), termPipe(adgrph.Descendents(seed)))
+	assert.Equal(termPipe(grph.Ancestors(seed)), termPipe(adgrph.Ancestors(seed)))
+
+	isa := NodeID("is_a")
+	upper := NodeID("SO_0001411")
+	bgrph, err := Extract(grph, []NodeID{seed}, &ExtractOptions{
+		Strategy:   ExtractMireotBottom,
+		Predicates: []NodeID{isa},
+		UpperTerms: []NodeID{upper},
+	})
+	assert.NoError(err, "expect no error from extracting bottom")
+	assert.True(bgrph.ExistsTerm(upper), "expect the upper term")
+	assert.Empty(bgrph.Parents(upper), "expect no parent of the upper term")
+	for _, rel := range bgrph.Relationships() {
+		assert.Equal(isa, rel.Predicate(), "expect is_a relationship")
+	}
+	assert.Less(len(bgrph.Terms()), len(agrph.Terms()), "expect fewer terms")
+
+	tgrph, err := Extract(grph, []NodeID{"SO_0001217"}, &ExtractOptions{
+		Strategy: ExtractMireotTop,
+	})
+	assert.NoError(err, "expect no error from extracting top")
+	assert.ElementsMatch(
+		append(termPipe(grph.Descendents("SO_0001217")), "SO_0001217"),
+		termPipe(tgrph.TermsByType("CLASS")),
+		"expect the seed and its descendants",
+	)
+
+	_, err = Extract(grph, []NodeID{"SO_9999999"}, nil)
+	assert.Error(err, "expect error for unknown seed")
+	_, err = Extract(grph, []NodeID{seed}, &ExtractOptions{Strategy: 10})
+	assert.Error(err, "expect error for unknown strategy")
+}
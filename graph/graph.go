@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/dictyBase/go-obograph/model"
 )
@@ -9,7 +10,15 @@ import (
 // NodeID is a custom type for holding a node id.
 type NodeID string
 
-// OboGraph is an interface for accessing OBO Graphs.
+// OboGraph is an interface for accessing OBO Graphs. All the methods that
+// return a list of terms or relationships keep a stable order. Terms are
+// listed in the order they were first added to the graph, the related terms
+// of a term(children, parents) follow the same order and relationships are
+// ordered by their parent(object) and then by their children(subject). The
+// order of the related terms and the relationships depends only on the order
+// of the terms, not on the order the relationships were added, so that it is
+// kept when a graph is written and read back or copied, whatever the
+// implementation.
 type OboGraph interface {
 	// IRI represents a stable URL for locating the source OWL formatted file
 	IRI() string
@@ -45,32 +54,73 @@ type OboGraph interface {
 	// DescendentsDFS returns all reachable(direct or indirect) children terms
 	// using DFS algorithm.
 	DescendentsDFS(NodeID) []Term
+	// EachTerm calls the function for every term, in the same order as Terms,
+	// until it returns false. The function must not modify the graph
+	EachTerm(func(Term) bool)
+	// EachRelationship calls the function for every relationship, in the same
+	// order as Relationships, until it returns false. The function must not
+	// modify the graph
+	EachRelationship(func(Relationship) bool)
+	// EachChild calls the function for every children term(depth one) along
+	// with the relationship to it until it returns false. The function must
+	// not modify the graph
+	EachChild(NodeID, func(Term, Relationship) bool)
+	// EachParent calls the function for every parent term(depth one) along
+	// with the relationship to it until it returns false. The function must
+	// not modify the graph
+	EachParent(NodeID, func(Term, Relationship) bool)
 	// AddRelationship creates relationship between terms, it overrides the
 	// existing terms and relationship
 	AddRelationship(Term, Term, Term) error
 	// AddRelationshipWithID creates relationship between existing terms
 	AddRelationshipWithID(NodeID, NodeID, NodeID) error
-	// AddTerm add a new Term to the graph overwriting any existing one
+	// AddTerm add a new Term to the graph overwriting any existing one, the
+	// relationships where the existing term is the parent, the children or
+	// the predicate are removed
 	AddTerm(Term)
+	// UpdateTerm replaces an existing term keeping its relationships, such
+	// as to change its meta
+	UpdateTerm(Term) error
+	// RemoveTerm removes a term from the graph. With cascade, all the
+	// relationships where the term is the parent, the children or the
+	// predicate are removed too, otherwise an error is returned if there is
+	// any such relationship
+	RemoveTerm(NodeID, bool) error
+	// RemoveRelationship removes the relationship between parent(object) and
+	// children(subject) with the given predicate
+	RemoveRelationship(NodeID, NodeID, NodeID) error
+	// ObsoleteTerm marks a term as deprecated, removes all of its
+	// relationships and records the replacement and the terms to consider
+	// instead of it
+	ObsoleteTerm(NodeID, NodeID, ...NodeID) error
 }
 
 type graph struct {
 	nodes     map[NodeID]Term
-	edgesDown map[NodeID]map[NodeID]Relationship
-	edgesUp   map[NodeID]map[NodeID]Relationship
+	order     []NodeID
+	seq       map[NodeID]int
+	lastSeq   int
+	edgesDown map[NodeID]*adjacency
+	edgesUp   map[NodeID]*adjacency
+	// relationships by their predicate, keyed by parent(object) and
+	// children(subject)
+	edgesPred map[NodeID]map[[2]NodeID]bool
 	meta      *model.Meta
 	id        string
 	lbl       string
 	iri       string
 }
 
-func newOboGraph(m *model.Meta, idn, iri string) OboGraph {
+func newOboGraph(m *model.Meta, idn, lbl, iri string) OboGraph {
 	return &graph{
 		nodes:     make(map[NodeID]Term),
-		edgesUp:   make(map[NodeID]map[NodeID]Relationship),
-		edgesDown: make(map[NodeID]map[NodeID]Relationship),
+		seq:       make(map[NodeID]int),
+		edgesUp:   make(map[NodeID]*adjacency),
+		edgesDown: make(map[NodeID]*adjacency),
+		edgesPred: make(map[NodeID]map[[2]NodeID]bool),
 		meta:      m,
 		id:        idn,
+		lbl:       lbl,
 		iri:       iri,
 	}
 }
@@ -97,9 +147,9 @@ func (g *graph) Meta() *model.Meta {
 
 // Terms returns all terms(node/vertex) in the graph.
 func (g *graph) Terms() []Term {
-	trm := make([]Term, 0)
-	for _, n := range g.nodes {
-		trm = append(trm, n)
+	trm := make([]Term, 0, len(g.order))
+	for _, id := range g.order {
+		trm = append(trm, g.nodes[id])
 	}
 
 	return trm
@@ -108,8 +158,8 @@ func (g *graph) Terms() []Term {
 // TermsByType provides a filtered list of specific terms.
 func (g *graph) TermsByType(rtype string) []Term {
 	trm := make([]Term, 0)
-	for _, n := range g.nodes {
-		if n.RdfType() == rtype {
+	for _, id := range g.order {
+		if n := g.nodes[id]; n.RdfType() == rtype {
 			trm = append(trm, n)
 		}
 	}
@@ -120,15 +170,53 @@ func (g *graph) TermsByType(rtype string) []Term {
 // Relationships returns all relationships(edges) in the graph.
 func (g *graph) Relationships() []Relationship {
 	var rel []Relationship
-	for id := range g.edgesDown {
-		for k := range g.edgesDown[id] {
-			rel = append(rel, g.edgesDown[id][k])
+	for _, id := range g.order {
+		if adj, ok := g.edgesDown[id]; ok {
+			rel = append(rel, adj.relationships()...)
 		}
 	}
 
 	return rel
 }
 
+// EachTerm calls the function for every term, in the same order as Terms,
+// until it returns false.
+func (g *graph) EachTerm(fn func(Term) bool) {
+	for _, id := range g.order {
+		if !fn(g.nodes[id]) {
+			return
+		}
+	}
+}
+
+// EachRelationship calls the function for every relationship, in the same
+// order as Relationships, until it returns false.
+func (g *graph) EachRelationship(fn func(Relationship) bool) {
+	for _, id := range g.order {
+		adj, ok := g.edgesDown[id]
+		if !ok {
+			continue
+		}
+		for _, nid := range adj.ids {
+			if !fn(adj.rels[nid]) {
+				return
+			}
+		}
+	}
+}
+
+// EachChild calls the function for every children term(depth one) along with
+// the relationship to it until it returns false.
+func (g *graph) EachChild(id NodeID, fn func(Term, Relationship) bool) {
+	g.eachTerm(id, g.edgesDown, fn)
+}
+
+// EachParent calls the function for every parent term(depth one) along with
+// the relationship to it until it returns false.
+func (g *graph) EachParent(id NodeID, fn func(Term, Relationship) bool) {
+	g.eachTerm(id, g.edgesUp, fn)
+}
+
 // Children returns all children terms(depth one).
 func (g *graph) Children(id NodeID) []Term {
 	return g.getTerms(id, g.edgesDown)
@@ -276,7 +364,7 @@ func (g *graph) GetTerm(id NodeID) Term {
 // children(subject).
 func (g *graph) GetRelationship(obj NodeID, subj NodeID) (rel Relationship) {
 	if v, ok := g.edgesDown[obj]; ok {
-		if r, ok := v[subj]; ok {
+		if r, ok := v.rels[subj]; ok {
 			return r
 		}
 	}
@@ -284,34 +372,38 @@ func (g *graph) GetRelationship(obj NodeID, subj NodeID) (rel Relationship) {
 	return rel
 }
 
-// AddTerm add a new Term to the graph overwriting any existing one.
+// AddTerm add a new Term to the graph overwriting any existing one, the
+// relationships of the existing term are removed.
 func (g *graph) AddTerm(t Term) {
+	if _, ok := g.nodes[t.ID()]; ok {
+		for _, rel := range g.termRelationships(t.ID()) {
+			g.removeEdge(rel.Object(), rel.Subject())
+		}
+	}
+	g.setTerm(t)
+}
+
+// UpdateTerm replaces an existing term keeping its relationships.
+func (g *graph) UpdateTerm(t Term) error {
+	if _, ok := g.nodes[t.ID()]; !ok {
+		return fmt.Errorf("node id %s does not exist", t.ID())
+	}
 	g.nodes[t.ID()] = t
+
+	return nil
 }
 
 // AddRelationship creates relationship between terms, it overrides the
 // existing terms and relationship.
 func (g *graph) AddRelationship(obj, subj, pred Term) error {
-	g.nodes[obj.ID()] = obj
-	g.nodes[subj.ID()] = subj
-	g.nodes[pred.ID()] = pred
-	rel := NewRelationship(
+	g.setTerm(obj)
+	g.setTerm(subj)
+	g.setTerm(pred)
+	g.addEdge(NewRelationship(
 		obj.ID(),
 		subj.ID(),
 		pred.ID(),
-	)
-	if v, ok := g.edgesDown[obj.ID()]; ok {
-		v[subj.ID()] = rel
-		g.edgesDown[obj.ID()] = v
-	} else {
-		g.edgesDown[obj.ID()] = map[NodeID]Relationship{subj.ID(): rel}
-	}
-	if v, ok := g.edgesUp[subj.ID()]; ok {
-		v[obj.ID()] = rel
-		g.edgesUp[subj.ID()] = v
-	} else {
-		g.edgesUp[subj.ID()] = map[NodeID]Relationship{obj.ID(): rel}
-	}
+	))
 
 	return nil
 }
@@ -327,34 +419,248 @@ func (g *graph) AddRelationshipWithID(obj, subj, pred NodeID) error {
 	if _, ok := g.nodes[pred]; !ok {
 		return fmt.Errorf("predicate node id %s does not exist", pred)
 	}
-	rel := NewRelationship(
+	g.addEdge(NewRelationship(
 		obj,
 		subj,
 		pred,
-	)
-	if v, ok := g.edgesDown[obj]; ok {
-		v[subj] = rel
-		g.edgesDown[obj] = v
-	} else {
-		g.edgesDown[obj] = map[NodeID]Relationship{subj: rel}
+	))
+
+	return nil
+}
+
+// RemoveTerm removes a term from the graph. With cascade, all the
+// relationships where the term is the parent, the children or the predicate
+// are removed too, otherwise an error is returned if there is any such
+// relationship.
+func (g *graph) RemoveTerm(idn NodeID, cascade bool) error {
+	if _, ok := g.nodes[idn]; !ok {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+	rels := g.termRelationships(idn)
+	if len(rels) > 0 && !cascade {
+		return fmt.Errorf(
+			"node id %s is used in %d relationships",
+			idn, len(rels),
+		)
 	}
-	if v, ok := g.edgesUp[subj]; ok {
-		v[obj] = rel
-		g.edgesUp[subj] = v
-	} else {
-		g.edgesUp[subj] = map[NodeID]Relationship{obj: rel}
+	for _, rel := range rels {
+		g.removeEdge(rel.Object(), rel.Subject())
 	}
+	delete(g.nodes, idn)
+	g.order = removeID(g.order, idn)
+	delete(g.seq, idn)
 
 	return nil
 }
 
-func (g *graph) getTerms(id NodeID, edges map[NodeID]map[NodeID]Relationship) []Term {
+// RemoveRelationship removes the relationship between parent(object) and
+// children(subject) with the given predicate.
+func (g *graph) RemoveRelationship(obj, subj, pred NodeID) error {
+	rel := g.GetRelationship(obj, subj)
+	if rel == nil || rel.Predicate() != pred {
+		return fmt.Errorf(
+			"relationship %s between %s and %s does not exist",
+			pred, obj, subj,
+		)
+	}
+	g.removeEdge(obj, subj)
+
+	return nil
+}
+
+// ObsoleteTerm marks a term as deprecated, removes all of its relationships
+// and records the replacement and the terms to consider instead of it. An
+// empty replacement is not recorded.
+func (g *graph) ObsoleteTerm(idn, replacedBy NodeID, consider ...NodeID) error {
+	trm, ok := g.nodes[idn]
+	if !ok {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+	for _, rel := range g.termRelationships(idn) {
+		if rel.Predicate() == idn {
+			return fmt.Errorf(
+				"node id %s is the predicate of relationship between %s and %s",
+				idn, rel.Object(), rel.Subject(),
+			)
+		}
+	}
+	for _, rel := range g.termRelationships(idn) {
+		g.removeEdge(rel.Object(), rel.Subject())
+	}
+	g.nodes[idn] = obsoleteTerm(g, trm, replacedBy, consider)
+
+	return nil
+}
+
+// setTerm adds or overwrites a term, an overwritten term keeps its position
+// and its relationships.
+func (g *graph) setTerm(t Term) {
+	if _, ok := g.nodes[t.ID()]; !ok {
+		g.order = append(g.order, t.ID())
+		g.lastSeq++
+		g.seq[t.ID()] = g.lastSeq
+	}
+	g.nodes[t.ID()] = t
+}
+
+func (g *graph) addEdge(rel Relationship) {
+	obj, subj := rel.Object(), rel.Subject()
+	key := [2]NodeID{obj, subj}
+	if old := g.GetRelationship(obj, subj); old != nil {
+		delete(g.edgesPred[old.Predicate()], key)
+	}
+	if _, ok := g.edgesPred[rel.Predicate()]; !ok {
+		g.edgesPred[rel.Predicate()] = make(map[[2]NodeID]bool)
+	}
+	g.edgesPred[rel.Predicate()][key] = true
+	if _, ok := g.edgesDown[obj]; !ok {
+		g.edgesDown[obj] = newAdjacency()
+	}
+	g.edgesDown[obj].add(subj, rel, g.seq)
+	if _, ok := g.edgesUp[subj]; !ok {
+		g.edgesUp[subj] = newAdjacency()
+	}
+	g.edgesUp[subj].add(obj, rel, g.seq)
+}
+
+func (g *graph) removeEdge(obj, subj NodeID) {
+	if rel := g.GetRelationship(obj, subj); rel != nil {
+		delete(g.edgesPred[rel.Predicate()], [2]NodeID{obj, subj})
+		if len(g.edgesPred[rel.Predicate()]) == 0 {
+			delete(g.edgesPred, rel.Predicate())
+		}
+	}
+	if adj, ok := g.edgesDown[obj]; ok {
+		adj.remove(subj)
+		if len(adj.ids) == 0 {
+			delete(g.edgesDown, obj)
+		}
+	}
+	if adj, ok := g.edgesUp[subj]; ok {
+		adj.remove(obj)
+		if len(adj.ids) == 0 {
+			delete(g.edgesUp, subj)
+		}
+	}
+}
+
+// termRelationships returns all relationships where the term is the parent,
+// the children or the predicate, looking only at the relationships of the
+// term in the indexes.
+func (g *graph) termRelationships(idn NodeID) []Relationship {
+	rels := make([]Relationship, 0)
+	if adj, ok := g.edgesDown[idn]; ok {
+		rels = append(rels, adj.relationships()...)
+	}
+	if adj, ok := g.edgesUp[idn]; ok {
+		for _, rel := range adj.relationships() {
+			// self loops are already collected
+			if rel.Object() != idn {
+				rels = append(rels, rel)
+			}
+		}
+	}
+	prels := make([]Relationship, 0, len(g.edgesPred[idn]))
+	for key := range g.edgesPred[idn] {
+		if key[0] != idn && key[1] != idn {
+			prels = append(prels, g.GetRelationship(key[0], key[1]))
+		}
+	}
+	// in the order of the relationships of the graph
+	sort.Slice(prels, func(i, j int) bool {
+		if prels[i].Object() != prels[j].Object() {
+			return g.seq[prels[i].Object()] < g.seq[prels[j].Object()]
+		}
+
+		return g.seq[prels[i].Subject()] < g.seq[prels[j].Subject()]
+	})
+
+	return append(rels, prels...)
+}
+
+func (g *graph) getTerms(id NodeID, edges map[NodeID]*adjacency) []Term {
 	trm := make([]Term, 0)
-	if _, ok := g.nodes[id]; ok {
-		for nid := range edges[id] {
+	if _, ok := g.nodes[id]; !ok {
+		return trm
+	}
+	if adj, ok := edges[id]; ok {
+		for _, nid := range adj.ids {
 			trm = append(trm, g.nodes[nid])
 		}
 	}
 
 	return trm
 }
+
+func (g *graph) eachTerm(
+	id NodeID,
+	edges map[NodeID]*adjacency,
+	fn func(Term, Relationship) bool,
+) {
+	if _, ok := g.nodes[id]; !ok {
+		return
+	}
+	adj, ok := edges[id]
+	if !ok {
+		return
+	}
+	for _, nid := range adj.ids {
+		if !fn(g.nodes[nid], adj.rels[nid]) {
+			return
+		}
+	}
+}
+
+// adjacency keeps the relationships of a term to its neighbouring terms in
+// the order the neighbours were added to the graph.
+type adjacency struct {
+	ids  []NodeID
+	rels map[NodeID]Relationship
+}
+
+func newAdjacency() *adjacency {
+	return &adjacency{rels: make(map[NodeID]Relationship)}
+}
+
+// add adds or overwrites the relationship to a neighbour, the neighbours are
+// kept sorted by their sequence of addition to the graph.
+func (a *adjacency) add(id NodeID, rel Relationship, seq map[NodeID]int) {
+	if _, ok := a.rels[id]; !ok {
+		pos := sort.Search(len(a.ids), func(i int) bool {
+			return seq[a.ids[i]] > seq[id]
+		})
+		a.ids = append(a.ids, "")
+		copy(a.ids[pos+1:], a.ids[pos:])
+		a.ids[pos] = id
+	}
+	a.rels[id] = rel
+}
+
+func (a *adjacency) remove(id NodeID) {
+	if _, ok := a.rels[id]; !ok {
+		return
+	}
+	delete(a.rels, id)
+	a.ids = removeID(a.ids, id)
+}
+
+func (a *adjacency) relationships() []Relationship {
+	rels := make([]Relationship, 0, len(a.ids))
+	for _, id := range a.ids {
+		rels = append(rels, a.rels[id])
+	}
+
+	return rels
+}
+
+// removeID removes the first occurrence of an id keeping the order of the
+// rest.
+func removeID(ids []NodeID, id NodeID) []NodeID {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+
+	return ids
+}
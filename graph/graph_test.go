@@ -344,3 +344,24 @@ func TestGraphRelationship(t *testing.T) {
 func termToID(trm Term) NodeID {
 	return trm.ID()
 }
+
+func TestGraphPredicateTraversal(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	term := NodeID("SO_0001217")
+	parents := termPipe(ParentsByPredicate(grph, term, NodeID("is_a")))
+	assert.Equal([]NodeID{"SO_0000704"}, parents, "expect only is_a parent")
+	parents = termPipe(ParentsByPredicate(grph, term))
+	assert.Lenf(parents, 2, "expect 2 parents got %d", len(parents))
+	children := termPipe(ChildrenByPredicate(grph, NodeID("SO_0000010"), NodeID("has_quality")))
+	assert.Contains(children, term, "expect has_quality child")
+	ancestors := termPipe(AncestorsByPredicate(grph, term, NodeID("is_a")))
+	assert.NotContains(ancestors, NodeID("SO_0000010"), "expect no has_quality ancestor")
+	assert.Contains(ancestors, NodeID("SO_0000704"), "expect is_a ancestor")
+	desc := termPipe(DescendentsByPredicate(grph, NodeID("SO_0000704"), NodeID("is_a")))
+	assert.Contains(desc, term, "expect is_a descendent")
+}
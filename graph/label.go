@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+)
+
+// LabelScope is the scope of the label of a term in a LabelMatch, the
+// synonyms have their OBO scope, EXACT, NARROW, BROAD or RELATED.
+const LabelScope = "LABEL"
+
+// LabelMatch is a term found by its label or one of its synonyms.
+type LabelMatch struct {
+	Term Term
+	// Text is the matched label or synonym
+	Text string
+	// Scope is LabelScope for a label, otherwise the scope of the synonym
+	Scope string
+	pos   int
+}
+
+// Ambiguity is a label shared by several terms.
+type Ambiguity struct {
+	// Text is the normalised label
+	Text string
+	// Terms are the ids of the terms having the label as their label or
+	// exact synonym
+	Terms []NodeID
+}
+
+// LabelIndex looks up terms by their labels and synonyms. The lookups rank
+// the matches by label, then exact synonyms and then the other synonyms,
+// current terms before the deprecated ones and then in the order of the
+// graph. Every term is returned once with its best match. The index reflects
+// the graph at the time it was built.
+type LabelIndex struct {
+	entries map[string][]*LabelMatch
+	keys    []string
+}
+
+// NewLabelIndex indexes the labels and synonyms of the terms of the graph.
+func NewLabelIndex(grph OboGraph) *LabelIndex {
+	idx := &LabelIndex{entries: make(map[string][]*LabelMatch)}
+	pos := 0
+	grph.EachTerm(func(trm Term) bool {
+		pos++
+		if len(trm.Label()) > 0 {
+			idx.add(&LabelMatch{Term: trm, Text: trm.Label(), Scope: LabelScope, pos: pos})
+		}
+		if !trm.HasMeta() {
+			return true
+		}
+		for _, syn := range trm.Meta().Synonyms() {
+			idx.add(&LabelMatch{Term: trm, Text: syn.Value(), Scope: syn.Scope(), pos: pos})
+		}
+
+		return true
+	})
+	idx.keys = make([]string, 0, len(idx.entries))
+	for key := range idx.entries {
+		idx.keys = append(idx.keys, key)
+	}
+	sort.Strings(idx.keys)
+
+	return idx
+}
+
+// NormalizeLabel lowercases a label and collapses the runs of whitespace and
+// underscores into a single space.
+func NormalizeLabel(lbl string) string {
+	return strings.Join(strings.Fields(
+		strings.ToLower(strings.ReplaceAll(lbl, "_", " ")),
+	), " ")
+}
+
+// Exact returns the terms whose label or synonym is exactly the text.
+func (l *LabelIndex) Exact(text string) []*LabelMatch {
+	mtch := make([]*LabelMatch, 0)
+	for _, m := range l.entries[NormalizeLabel(text)] {
+		if m.Text == text {
+			mtch = append(mtch, m)
+		}
+	}
+
+	return rankMatches(mtch, false)
+}
+
+// Lookup returns the terms whose label or synonym matches the text ignoring
+// case, whitespace and underscores.
+func (l *LabelIndex) Lookup(text string) []*LabelMatch {
+	return rankMatches(append([]*LabelMatch(nil), l.entries[NormalizeLabel(text)]...), false)
+}
+
+// Prefix returns the terms whose normalised label or synonym starts with the
+// normalised text, the shorter ones first within a rank. A non-positive
+// limit returns all matches.
+func (l *LabelIndex) Prefix(text string, limit int) []*LabelMatch {
+	prefix := NormalizeLabel(text)
+	mtch := make([]*LabelMatch, 0)
+	for i := sort.SearchStrings(l.keys, prefix); i < len(l.keys); i++ {
+		if !strings.HasPrefix(l.keys[i], prefix) {
+			break
+		}
+		mtch = append(mtch, l.entries[l.keys[i]]...)
+	}
+	mtch = rankMatches(mtch, true)
+	if limit > 0 && len(mtch) > limit {
+		mtch = mtch[:limit]
+	}
+
+	return mtch
+}
+
+// Ambiguous returns the normalised labels that belong to more than one
+// current term, either as label or exact synonym, sorted by label.
+func (l *LabelIndex) Ambiguous() []*Ambiguity {
+	amb := make([]*Ambiguity, 0)
+	for _, key := range l.keys {
+		ids := make([]NodeID, 0)
+		for _, m := range rankMatches(append([]*LabelMatch(nil), l.entries[key]...), false) {
+			if m.Term.IsDeprecated() || (m.Scope != LabelScope && m.Scope != "EXACT") {
+				continue
+			}
+			ids = append(ids, m.Term.ID())
+		}
+		if len(ids) > 1 {
+			amb = append(amb, &Ambiguity{Text: key, Terms: ids})
+		}
+	}
+
+	return amb
+}
+
+func (l *LabelIndex) add(mtch *LabelMatch) {
+	key := NormalizeLabel(mtch.Text)
+	l.entries[key] = append(l.entries[key], mtch)
+}
+
+// rankMatches sorts the matches by rank keeping the best match of every
+// term, optionally ranking the shorter texts first.
+func rankMatches(mtch []*LabelMatch, shorter bool) []*LabelMatch {
+	sort.Slice(mtch, func(i, j int) bool {
+		ri, rj := matchRank(mtch[i]), matchRank(mtch[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if di, dj := mtch[i].Term.IsDeprecated(), mtch[j].Term.IsDeprecated(); di != dj {
+			return dj
+		}
+		if li, lj := len(mtch[i].Text), len(mtch[j].Text); shorter && li != lj {
+			return li < lj
+		}
+		if mtch[i].pos != mtch[j].pos {
+			return mtch[i].pos < mtch[j].pos
+		}
+
+		return mtch[i].Text < mtch[j].Text
+	})
+	seen := make(map[NodeID]bool)
+	uniq := make([]*LabelMatch, 0, len(mtch))
+	for _, m := range mtch {
+		if !seen[m.Term.ID()] {
+			seen[m.Term.ID()] = true
+			uniq = append(uniq, m)
+		}
+	}
+
+	return uniq
+}
+
+func matchRank(mtch *LabelMatch) int {
+	switch mtch.Scope {
+	case LabelScope:
+		return 0
+	case "EXACT":
+		return 1
+	default:
+		return 2
+	}
+}
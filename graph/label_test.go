@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func matchIDs(mtch []*LabelMatch) []NodeID {
+	ids := make([]NodeID, 0, len(mtch))
+	for _, m := range mtch {
+		ids = append(ids, m.Term.ID())
+	}
+
+	return ids
+}
+
+func TestLabelIndex(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	idx := NewLabelIndex(grph)
+	assert.Equal("protein coding gene", NormalizeLabel("  Protein_Coding \t gene "))
+
+	mtch := idx.Exact("gene")
+	assert.Len(mtch, 1, "expect one exact match")
+	assert.Equal(NodeID("SO_0000704"), mtch[0].Term.ID())
+	assert.Equal(LabelScope, mtch[0].Scope)
+	assert.Empty(idx.Exact("Gene"), "expect exact match to be case sensitive")
+
+	mtch = idx.Lookup("Protein Coding  Gene")
+	assert.Len(mtch, 1, "expect one term for label and synonym")
+	assert.Equal(NodeID("SO_0001217"), mtch[0].Term.ID())
+	assert.Equal(LabelScope, mtch[0].Scope, "expect label to rank over synonym")
+	assert.Equal("protein_coding_gene", mtch[0].Text)
+
+	mtch = idx.Lookup("insdc feature:gene")
+	assert.Equal(NodeID("SO_0000704"), mtch[0].Term.ID(), "expect term from synonym")
+	assert.Equal("EXACT", mtch[0].Scope)
+
+	mtch = idx.Prefix("protein cod", 0)
+	assert.NotEmpty(mtch, "expect prefix matches")
+	assert.Equal(NodeID("SO_0000010"), mtch[0].Term.ID(), "expect shortest label first")
+	assert.Equal("protein_coding", mtch[0].Text)
+	for _, m := range mtch {
+		assert.Contains(NormalizeLabel(m.Text), "protein cod")
+	}
+	assert.Len(idx.Prefix("protein", 3), 3, "expect limited matches")
+	assert.Empty(idx.Lookup("no such label"), "expect no match")
+
+	amb := idx.Ambiguous()
+	assert.NotEmpty(amb, "expect ambiguous labels")
+	found := false
+	for _, a := range amb {
+		assert.Greater(len(a.Terms), 1, "expect several terms")
+		if a.Text == "intron" {
+			found = true
+			assert.Equal([]NodeID{"SO_0000188", "SO_0001627"}, a.Terms, "expect label before synonym")
+		}
+	}
+	assert.True(found, "expect intron to be ambiguous")
+}
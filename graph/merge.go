@@ -0,0 +1,421 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// MergePolicy decides how the terms and relationships that differ between
+// the merged graphs are reconciled.
+type MergePolicy int
+
+const (
+	// PreferFirst keeps the term or relationship of the first graph that has
+	// it.
+	PreferFirst MergePolicy = iota
+	// PreferLatestVersion keeps the term or relationship of the graph with
+	// the latest release. The release is the date of a dated version IRI of
+	// OBO, such as .../so/2021-11-22/so.owl, or else its last directory, so
+	// that the name of the ontology does not decide. The first graph wins a
+	// tie.
+	PreferLatestVersion
+	// ReportConflict fails the merge when any term or relationship differs.
+	ReportConflict
+)
+
+var (
+	releaseDate    = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	releaseNumbers = regexp.MustCompile(`\d+`)
+)
+
+// Field names of a term that are compared for conflicts and changes.
+const (
+	FieldLabel       = "label"
+	FieldType        = "type"
+	FieldDefinition  = "definition"
+	FieldSynonyms    = "synonyms"
+	FieldXrefs       = "xrefs"
+	FieldComments    = "comments"
+	FieldSubsets     = "subsets"
+	FieldProperties  = "properties"
+	FieldDeprecation = "deprecated"
+)
+
+// Conflict describes a term that differs between the merged graphs.
+type Conflict struct {
+	// ID of the term
+	ID NodeID
+	// Fields are the names of the fields that differ
+	Fields []string
+	// Sources are the indices of the merged graphs that have the term, in
+	// the order of merging
+	Sources []int
+}
+
+// EdgeConflict describes a pair of terms that are related through different
+// predicates in the merged graphs.
+type EdgeConflict struct {
+	Object  NodeID
+	Subject NodeID
+	// Predicates are the predicates of the relationship, one for every
+	// source
+	Predicates []NodeID
+	// Sources are the indices of the merged graphs that relate the terms, in
+	// the order of merging
+	Sources []int
+}
+
+// MergeResult is the outcome of merging graphs.
+type MergeResult struct {
+	// Graph is the merged graph
+	Graph OboGraph
+	// Sources maps every term to the index of the merged graph it was taken
+	// from, as successive versions of an ontology share the same graph id
+	Sources map[NodeID]int
+	// Conflicts are the terms that differ between the graphs, in the order
+	// of the merged terms
+	Conflicts []*Conflict
+	// EdgeConflicts are the relationships that differ between the graphs
+	EdgeConflicts []*EdgeConflict
+}
+
+// MergeConflictError is returned by the ReportConflict policy when the
+// merged graphs differ.
+type MergeConflictError struct {
+	Conflicts     []*Conflict
+	EdgeConflicts []*EdgeConflict
+}
+
+func (e *MergeConflictError) Error() string {
+	ids := make([]string, 0, len(e.Conflicts)+len(e.EdgeConflicts))
+	for _, cnf := range e.Conflicts {
+		ids = append(ids, fmt.Sprintf("%s(%s)", cnf.ID, strings.Join(cnf.Fields, ",")))
+	}
+	for _, cnf := range e.EdgeConflicts {
+		ids = append(ids, fmt.Sprintf("%s-%s", cnf.Object, cnf.Subject))
+	}
+
+	return fmt.Sprintf(
+		"graphs have %d conflicting term(s) and %d conflicting relationship(s): %s",
+		len(e.Conflicts), len(e.EdgeConflicts), strings.Join(ids, "; "),
+	)
+}
+
+// Merge unions the terms and relationships of the graphs into a new graph.
+// The terms keep the order they are first seen in. As a graph can relate two
+// terms only once, the relationships through different predicates are
+// reconciled by the policy like the terms. The policy chooses the graph the
+// merged graph takes its id, label, IRI and meta from too, the first one
+// unless PreferLatestVersion finds a later release.
+func Merge(policy MergePolicy, grphs ...OboGraph) (*MergeResult, error) {
+	if len(grphs) == 0 {
+		return nil, fmt.Errorf("no graph to merge")
+	}
+	mrg := &merger{
+		policy:   policy,
+		grphs:    grphs,
+		chosen:   make(map[NodeID]int),
+		conflict: make(map[NodeID]*Conflict),
+		edges:    make(map[[2]NodeID]*mergedEdge),
+	}
+	for gdx, grph := range grphs {
+		grph.EachTerm(func(trm Term) bool {
+			mrg.addTerm(gdx, trm)
+
+			return true
+		})
+	}
+	for gdx, grph := range grphs {
+		grph.EachRelationship(func(rel Relationship) bool {
+			mrg.addRelationship(gdx, rel)
+
+			return true
+		})
+	}
+	if policy == ReportConflict && (len(mrg.conflicts) > 0 || len(mrg.edgeConflicts) > 0) {
+		return nil, &MergeConflictError{
+			Conflicts:     mrg.conflicts,
+			EdgeConflicts: mrg.edgeConflicts,
+		}
+	}
+
+	return mrg.result()
+}
+
+type mergedEdge struct {
+	gdx      int
+	rel      Relationship
+	conflict *EdgeConflict
+}
+
+type merger struct {
+	policy        MergePolicy
+	grphs         []OboGraph
+	order         []NodeID
+	chosen        map[NodeID]int
+	conflict      map[NodeID]*Conflict
+	conflicts     []*Conflict
+	edgeOrder     [][2]NodeID
+	edges         map[[2]NodeID]*mergedEdge
+	edgeConflicts []*EdgeConflict
+}
+
+func (m *merger) addTerm(gdx int, trm Term) {
+	cdx, ok := m.chosen[trm.ID()]
+	if !ok {
+		m.chosen[trm.ID()] = gdx
+		m.order = append(m.order, trm.ID())
+
+		return
+	}
+	fields := TermChanges(m.grphs[cdx].GetTerm(trm.ID()), trm)
+	if len(fields) == 0 {
+		return
+	}
+	cnf, ok := m.conflict[trm.ID()]
+	if !ok {
+		cnf = &Conflict{ID: trm.ID(), Sources: []int{cdx}}
+		m.conflict[trm.ID()] = cnf
+		m.conflicts = append(m.conflicts, cnf)
+	}
+	cnf.Sources = append(cnf.Sources, gdx)
+	for _, fld := range fields {
+		if !hasField(cnf.Fields, fld) {
+			cnf.Fields = append(cnf.Fields, fld)
+		}
+	}
+	if m.prefer(gdx, cdx) {
+		m.chosen[trm.ID()] = gdx
+	}
+}
+
+func (m *merger) addRelationship(gdx int, rel Relationship) {
+	key := [2]NodeID{rel.Object(), rel.Subject()}
+	edg, ok := m.edges[key]
+	if !ok {
+		m.edges[key] = &mergedEdge{gdx: gdx, rel: rel}
+		m.edgeOrder = append(m.edgeOrder, key)
+
+		return
+	}
+	if edg.rel.Predicate() == rel.Predicate() {
+		return
+	}
+	if edg.conflict == nil {
+		edg.conflict = &EdgeConflict{
+			Object:     rel.Object(),
+			Subject:    rel.Subject(),
+			Predicates: []NodeID{edg.rel.Predicate()},
+			Sources:    []int{edg.gdx},
+		}
+		m.edgeConflicts = append(m.edgeConflicts, edg.conflict)
+	}
+	edg.conflict.Predicates = append(edg.conflict.Predicates, rel.Predicate())
+	edg.conflict.Sources = append(edg.conflict.Sources, gdx)
+	if m.prefer(gdx, edg.gdx) {
+		edg.gdx = gdx
+		edg.rel = rel
+	}
+}
+
+// prefer tells whether the graph at gdx wins over the graph at cdx.
+func (m *merger) prefer(gdx, cdx int) bool {
+	if m.policy != PreferLatestVersion {
+		return false
+	}
+
+	return compareVersions(GraphVersion(m.grphs[gdx]), GraphVersion(m.grphs[cdx])) > 0
+}
+
+func (m *merger) result() (*MergeResult, error) {
+	chosen := 0
+	for gdx := range m.grphs {
+		if m.prefer(gdx, chosen) {
+			chosen = gdx
+		}
+	}
+	src := m.grphs[chosen]
+	var meta *model.Meta
+	if src.Meta() != nil {
+		meta = model.NewMeta(src.Meta().Options())
+	}
+	mgrph := newOboGraph(meta, src.ID(), src.Label(), src.IRI())
+	sources := make(map[NodeID]int)
+	for _, idn := range m.order {
+		mgrph.AddTerm(m.grphs[m.chosen[idn]].GetTerm(idn))
+		sources[idn] = m.chosen[idn]
+	}
+	for _, key := range m.edgeOrder {
+		rel := m.edges[key].rel
+		err := mgrph.AddRelationshipWithID(
+			rel.Object(),
+			rel.Subject(),
+			rel.Predicate(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error in adding relationship %s", err)
+		}
+	}
+
+	return &MergeResult{
+		Graph:         mgrph,
+		Sources:       sources,
+		Conflicts:     m.conflicts,
+		EdgeConflicts: m.edgeConflicts,
+	}, nil
+}
+
+// GraphVersion returns the version of a graph, empty when it has no meta.
+func GraphVersion(grph OboGraph) string {
+	if grph.Meta() == nil {
+		return ""
+	}
+
+	return grph.Meta().Version()
+}
+
+// releaseVersion extracts the release from a version, the date of a dated
+// version IRI or the last directory of any other IRI.
+func releaseVersion(version string) string {
+	if dte := releaseDate.FindString(version); len(dte) > 0 {
+		return dte
+	}
+	segs := strings.Split(strings.TrimSuffix(version, "/"), "/")
+	if len(segs) > 1 {
+		return segs[len(segs)-2]
+	}
+
+	return version
+}
+
+// compareVersions compares the releases of two versions by their numbers,
+// such as the parts of a date or of a version like v1.10, and then as
+// strings. It returns a negative number when the first one is older, zero
+// when they are the same and a positive number otherwise.
+func compareVersions(first, second string) int {
+	frel, srel := releaseVersion(first), releaseVersion(second)
+	fnum, snum := releaseNumbers.FindAllString(frel, -1), releaseNumbers.FindAllString(srel, -1)
+	for i := 0; i < len(fnum) && i < len(snum); i++ {
+		fval, _ := strconv.Atoi(fnum[i])
+		sval, _ := strconv.Atoi(snum[i])
+		if fval != sval {
+			return fval - sval
+		}
+	}
+	if len(fnum) != len(snum) {
+		return len(fnum) - len(snum)
+	}
+
+	return strings.Compare(frel, srel)
+}
+
+// TermChanges returns the names of the fields that differ between two
+// versions of a term.
+func TermChanges(old, cur Term) []string {
+	fields := make([]string, 0)
+	if old.Label() != cur.Label() {
+		fields = append(fields, FieldLabel)
+	}
+	if old.RdfType() != cur.RdfType() {
+		fields = append(fields, FieldType)
+	}
+	oldOpt, curOpt := old.Meta().Options(), cur.Meta().Options()
+	if !sameDefinition(oldOpt.Definition, curOpt.Definition) {
+		fields = append(fields, FieldDefinition)
+	}
+	if !sameSynonyms(oldOpt.Synonyms, curOpt.Synonyms) {
+		fields = append(fields, FieldSynonyms)
+	}
+	if !sameStrings(xrefValues(oldOpt.Xrefs), xrefValues(curOpt.Xrefs)) {
+		fields = append(fields, FieldXrefs)
+	}
+	if !sameStrings(oldOpt.Comments, curOpt.Comments) {
+		fields = append(fields, FieldComments)
+	}
+	if !sameStrings(oldOpt.Subsets, curOpt.Subsets) {
+		fields = append(fields, FieldSubsets)
+	}
+	if !sameProperties(oldOpt.BaseProps, curOpt.BaseProps) {
+		fields = append(fields, FieldProperties)
+	}
+	if oldOpt.Deprecated != curOpt.Deprecated {
+		fields = append(fields, FieldDeprecation)
+	}
+
+	return fields
+}
+
+func sameDefinition(old, cur *model.Definition) bool {
+	if old == nil || cur == nil {
+		return old == nil && cur == nil
+	}
+
+	return old.Value() == cur.Value() && sameStrings(old.Xrefs(), cur.Xrefs())
+}
+
+func sameSynonyms(old, cur []*model.Synonym) bool {
+	if len(old) != len(cur) {
+		return false
+	}
+	for i := range old {
+		if old[i].Pred() != cur[i].Pred() ||
+			old[i].Value() != cur[i].Value() ||
+			!sameStrings(old[i].Xrefs(), cur[i].Xrefs()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameProperties(old, cur []*model.BasicPropertyValue) bool {
+	if len(old) != len(cur) {
+		return false
+	}
+	for i := range old {
+		if old[i].Pred() != cur[i].Pred() || old[i].Value() != cur[i].Value() {
+			return false
+		}
+		if old[i].ValType() != cur[i].ValType() || old[i].Lang() != cur[i].Lang() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameStrings(old, cur []string) bool {
+	if len(old) != len(cur) {
+		return false
+	}
+	for i := range old {
+		if old[i] != cur[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func xrefValues(xrefs []*model.Xref) []string {
+	vals := make([]string, 0, len(xrefs))
+	for _, x := range xrefs {
+		vals = append(vals, x.Value())
+	}
+
+	return vals
+}
+
+func hasField(fields []string, fld string) bool {
+	for _, f := range fields {
+		if f == fld {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func importGraph(assert *require.Assertions, grph OboGraph) OboGraph {
+	igrph := newOboGraph(
+		model.NewMeta(&model.MetaOptions{
+			Version: "http://purl.obolibrary.org/obo/so/2099-01-01/so.owl",
+		}),
+		"import", "", "http://purl.obolibrary.org/obo/import.owl",
+	)
+	gene := grph.GetTerm("SO_0000704")
+	region := grph.GetTerm("SO_0001411")
+	partOf := grph.GetTerm("part_of")
+	isa := grph.GetTerm("is_a")
+	assert.NoError(igrph.AddRelationship(
+		region,
+		NewTermWithMeta(gene.ID(), gene.Meta(), gene.RdfType(), "gene region", gene.IRI()),
+		partOf,
+	))
+	assert.NoError(igrph.AddRelationship(
+		igrph.GetTerm("SO_0000704"),
+		NewTerm("TEST_0000001", "CLASS", "test gene", "http://purl.obolibrary.org/obo/TEST_0000001"),
+		isa,
+	))
+
+	return igrph
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	assert.Equal(NodeID("is_a"), grph.GetRelationship("SO_0001411", "SO_0000704").Predicate())
+	igrph := importGraph(assert, grph)
+
+	res, err := Merge(PreferFirst, grph, igrph)
+	assert.NoError(err, "expect no error from merging")
+	mgrph := res.Graph
+	assert.Len(mgrph.Terms(), len(grph.Terms())+1, "expect one new term")
+	assert.Len(mgrph.Relationships(), len(grph.Relationships())+1, "expect one new relationship")
+	assert.Equal(grph.ID(), mgrph.ID(), "expect id of the first graph")
+	assert.Equal("gene", mgrph.GetTerm("SO_0000704").Label(), "expect label of the first graph")
+	assert.Equal(NodeID("is_a"), mgrph.GetRelationship("SO_0001411", "SO_0000704").Predicate())
+	assert.Contains(termPipe(mgrph.Children("SO_0000704")), NodeID("TEST_0000001"))
+	assert.Equal(1, res.Sources["TEST_0000001"], "expect source of new term")
+	assert.Equal(0, res.Sources["SO_0000704"], "expect source of first graph")
+	assert.Len(res.Conflicts, 1, "expect one conflicting term")
+	assert.Equal(NodeID("SO_0000704"), res.Conflicts[0].ID)
+	assert.Equal([]string{FieldLabel}, res.Conflicts[0].Fields)
+	assert.Equal([]int{0, 1}, res.Conflicts[0].Sources)
+	assert.Len(res.EdgeConflicts, 1, "expect one conflicting relationship")
+	assert.Equal([]NodeID{"is_a", "part_of"}, res.EdgeConflicts[0].Predicates)
+	assert.Equal([]int{0, 1}, res.EdgeConflicts[0].Sources)
+
+	res, err = Merge(PreferLatestVersion, grph, igrph)
+	assert.NoError(err, "expect no error from merging")
+	assert.Equal("gene region", res.Graph.GetTerm("SO_0000704").Label(), "expect latest label")
+	assert.Equal(1, res.Sources["SO_0000704"], "expect source of latest graph")
+	assert.Equal("import", res.Graph.ID(), "expect id of the latest graph")
+	assert.Equal(GraphVersion(igrph), GraphVersion(res.Graph), "expect meta of the latest graph")
+	assert.Equal(
+		NodeID("part_of"),
+		res.Graph.GetRelationship("SO_0001411", "SO_0000704").Predicate(),
+		"expect latest relationship",
+	)
+
+	_, err = Merge(ReportConflict, grph, igrph)
+	assert.Error(err, "expect conflict error")
+	cerr, ok := err.(*MergeConflictError)
+	assert.True(ok, "expect MergeConflictError")
+	assert.Len(cerr.Conflicts, 1, "expect one conflicting term")
+	res, err = Merge(ReportConflict, grph, grph)
+	assert.NoError(err, "expect no conflict for the same graph")
+	assertSameGraph(assert, grph, res.Graph)
+	_, err = Merge(PreferFirst)
+	assert.Error(err, "expect error without graphs")
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	sov := "http://purl.obolibrary.org/obo/so/2021-11-22/so.owl"
+	rov := "http://purl.obolibrary.org/obo/ro/releases/2023-08-18/ro.owl"
+	assert.Negative(compareVersions(sov, rov), "expect the older release to lose")
+	assert.Positive(compareVersions(rov, sov), "expect the newer release to win")
+	assert.Zero(compareVersions(sov, "http://purl.obolibrary.org/obo/ro/releases/2021-11-22/ro.owl"))
+	assert.Positive(compareVersions("http://example.org/v1.10/x.owl", "http://example.org/v1.9/x.owl"))
+	assert.Positive(compareVersions("2021-11-22", ""), "expect a release to win over none")
+}
+
+func TestMergeWithoutMeta(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	first := newOboGraph(nil, "first", "", "http://example.org/first.owl")
+	first.AddTerm(NewTerm("TEST_1", "CLASS", "test1", "test1"))
+	second := newOboGraph(nil, "second", "", "http://example.org/second.owl")
+	second.AddTerm(NewTerm("TEST_2", "CLASS", "test2", "test2"))
+	res, err := Merge(PreferLatestVersion, first, second)
+	assert.NoError(err, "expect no error from merging graphs without meta")
+	assert.Nil(res.Graph.Meta(), "expect no meta")
+	assert.Len(res.Graph.Terms(), 2, "expect terms of both graphs")
+}
+
+func TestMergeVersionsOfGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	versions := make([]OboGraph, 0, 2)
+	for _, rel := range []string{"2021-11-22", "2022-03-01"} {
+		grph := newOboGraph(
+			model.NewMeta(&model.MetaOptions{
+				Version: "http://purl.obolibrary.org/obo/test/" + rel + "/test.owl",
+			}),
+			"test", "test "+rel, "http://purl.obolibrary.org/obo/test.owl",
+		)
+		grph.AddTerm(NewTerm("TEST_1", "CLASS", "test "+rel, "test1"))
+		versions = append(versions, grph)
+	}
+	res, err := Merge(PreferLatestVersion, versions...)
+	assert.NoError(err, "expect no error from merging versions")
+	assert.Equal(1, res.Sources["TEST_1"], "expect the index of the latest version")
+	assert.Equal([]int{0, 1}, res.Conflicts[0].Sources, "expect distinct sources of the same graph id")
+	assert.Equal("test 2022-03-01", res.Graph.Label(), "expect the label of the latest version")
+	assert.Equal(GraphVersion(versions[1]), GraphVersion(res.Graph), "expect the meta of the latest version")
+	res, err = Merge(PreferFirst, versions...)
+	assert.NoError(err, "expect no error from merging versions")
+	assert.Equal("test 2021-11-22", res.Graph.Label(), "expect the label of the first version")
+	assert.Equal(0, res.Sources["TEST_1"], "expect the index of the first version")
+}
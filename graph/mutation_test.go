@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphRemoveTerm(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	term := NodeID("SO_0001217")
+	nrels := len(grph.Relationships())
+	assert.Error(grph.RemoveTerm(term, false), "expect error from term with relationships")
+	assert.True(grph.ExistsTerm(term), "expect term to be kept")
+	assert.NoError(grph.RemoveTerm(term, true), "expect no error from cascading removal")
+	assert.False(grph.ExistsTerm(term), "expect term to be removed")
+	assert.Len(grph.Relationships(), nrels-6, "expect 6 relationships to be removed")
+	assert.NotContains(termPipe(grph.Children("SO_0000704")), term)
+	assert.NotContains(termPipe(grph.Parents("SO_0000548")), term)
+	assert.Nil(grph.GetRelationship("SO_0000704", term), "expect no relationship")
+	assert.Error(grph.RemoveTerm(term, true), "expect error from removing absent term")
+	assert.Error(grph.RemoveTerm("has_quality", false), "expect error from predicate in use")
+	grph.AddTerm(NewTerm("SO_9999999", "CLASS", "new", "new"))
+	assert.NoError(grph.RemoveTerm("SO_9999999", false), "expect removal of term without relationships")
+}
+
+func TestGraphRemoveRelationship(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	obj, subj := NodeID("SO_0000704"), NodeID("SO_0001217")
+	assert.Error(
+		grph.RemoveRelationship(obj, subj, "part_of"),
+		"expect error from mismatched predicate",
+	)
+	assert.NoError(grph.RemoveRelationship(obj, subj, "is_a"))
+	assert.Nil(grph.GetRelationship(obj, subj), "expect relationship to be removed")
+	assert.NotContains(termPipe(grph.Parents(subj)), obj)
+	assert.NotContains(termPipe(grph.Children(obj)), subj)
+	assert.Error(grph.RemoveRelationship(obj, subj, "is_a"), "expect error from absent relationship")
+}
+
+func TestGraphObsoleteTerm(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	term := NodeID("SO_0001217")
+	assert.NoError(grph.ObsoleteTerm(term, "SO_0000704", "SO_0001263", "SO_0000010"))
+	trm := grph.GetTerm(term)
+	assert.True(trm.IsDeprecated(), "expect term to be deprecated")
+	assert.Equal("protein_coding_gene", trm.Label(), "expect label to be kept")
+	assert.Empty(grph.Parents(term), "expect no parents")
+	assert.Empty(grph.Children(term), "expect no children")
+	replaced := make([]string, 0)
+	consider := make([]string, 0)
+	deprecated := false
+	for _, prop := range trm.Meta().BasicPropertyValues() {
+		switch prop.Pred() {
+		case model.DeprecatedIRI:
+			deprecated, _ = prop.Bool()
+		case model.ReplacedByIRI:
+			replaced = append(replaced, prop.Value())
+		case model.ConsiderIRI:
+			consider = append(consider, prop.Value())
+		}
+	}
+	assert.Equal([]string{"http://purl.obolibrary.org/obo/SO_0000704"}, replaced)
+	assert.Len(consider, 2, "expect two terms to consider")
+	assert.True(deprecated, "expect owl:deprecated property")
+	assert.Equal("sequence", trm.Meta().Namespace(), "expect meta to be kept")
+	assert.Error(grph.ObsoleteTerm("SO_9999999", ""), "expect error from absent term")
+	assert.Error(grph.ObsoleteTerm("is_a", ""), "expect error from predicate in use")
+}
+
+func TestGraphReplaceTerm(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	term := NodeID("SO_0001217")
+	nrels := len(grph.Relationships())
+	assert.NoError(grph.UpdateTerm(NewTerm(term, "CLASS", "renamed", "renamed")))
+	assert.Equal("renamed", grph.GetTerm(term).Label(), "expect updated term")
+	assert.Len(grph.Relationships(), nrels, "expect updated term to keep relationships")
+	assert.Error(grph.UpdateTerm(NewTerm("SO_9999999", "CLASS", "new", "new")), "expect error from absent term")
+	grph.AddTerm(NewTerm(term, "CLASS", "replaced", "replaced"))
+	assert.Equal("replaced", grph.GetTerm(term).Label(), "expect replaced term")
+	assert.Len(grph.Relationships(), nrels-6, "expect replaced term to lose its 6 relationships")
+	assert.Empty(grph.Parents(term), "expect no parents")
+	assert.Empty(grph.Children(term), "expect no children")
+	pred := NodeID("has_quality")
+	npred := 0
+	grph.EachRelationship(func(rel Relationship) bool {
+		if rel.Predicate() == pred {
+			npred++
+		}
+
+		return true
+	})
+	assert.Positive(npred, "expect relationships with the predicate")
+	grph.AddTerm(grph.GetTerm(pred))
+	grph.EachRelationship(func(rel Relationship) bool {
+		assert.NotEqual(pred, rel.Predicate(), "expect no relationship with the replaced predicate")
+
+		return true
+	})
+	assert.Len(grph.Relationships(), nrels-6-npred, "expect the relationships of the predicate removed")
+}
@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+const defaultNamespacePred = "http://www.geneontology.org/formats/oboInOwl#default-namespace"
+
+// NamespaceStats are the statistics of the class terms of a namespace.
+type NamespaceStats struct {
+	Namespace  string
+	Terms      int
+	Deprecated int
+	// Relationships are between terms of the namespace
+	Relationships int
+	// CrossRelationships have their subject in the namespace and their
+	// object in another one
+	CrossRelationships int
+}
+
+// TermNamespace returns the namespace of a term, or the default namespace of
+// the graph when the term has none.
+func TermNamespace(grph OboGraph, trm Term) string {
+	if trm.HasMeta() {
+		if nsp := trm.Meta().Namespace(); len(nsp) > 0 {
+			return nsp
+		}
+	}
+	if grph.Meta() != nil {
+		return grph.Meta().Namespace()
+	}
+
+	return ""
+}
+
+// Namespaces returns the namespaces of the class terms of the graph, sorted.
+func Namespaces(grph OboGraph) []string {
+	seen := make(map[string]bool)
+	grph.EachTerm(func(trm Term) bool {
+		if trm.RdfType() == "CLASS" {
+			seen[TermNamespace(grph, trm)] = true
+		}
+
+		return true
+	})
+	nsps := make([]string, 0, len(seen))
+	for nsp := range seen {
+		nsps = append(nsps, nsp)
+	}
+	sort.Strings(nsps)
+
+	return nsps
+}
+
+// TermsByNamespace returns the class terms of the namespace in the order of
+// the graph.
+func TermsByNamespace(grph OboGraph, nsp string) []Term {
+	trms := make([]Term, 0)
+	grph.EachTerm(func(trm Term) bool {
+		if trm.RdfType() == "CLASS" && TermNamespace(grph, trm) == nsp {
+			trms = append(trms, trm)
+		}
+
+		return true
+	})
+
+	return trms
+}
+
+// NamespaceStatistics returns the statistics of every namespace of the
+// graph, sorted by namespace.
+func NamespaceStatistics(grph OboGraph) []*NamespaceStats {
+	nsm := classNamespaces(grph)
+	stats := make(map[string]*NamespaceStats)
+	get := func(nsp string) *NamespaceStats {
+		if _, ok := stats[nsp]; !ok {
+			stats[nsp] = &NamespaceStats{Namespace: nsp}
+		}
+
+		return stats[nsp]
+	}
+	grph.EachTerm(func(trm Term) bool {
+		if nsp, ok := nsm[trm.ID()]; ok {
+			get(nsp).Terms++
+			if trm.IsDeprecated() {
+				get(nsp).Deprecated++
+			}
+		}
+
+		return true
+	})
+	grph.EachRelationship(func(rel Relationship) bool {
+		snsp, sok := nsm[rel.Subject()]
+		onsp, ook := nsm[rel.Object()]
+		switch {
+		case !sok || !ook:
+		case snsp == onsp:
+			get(snsp).Relationships++
+		default:
+			get(snsp).CrossRelationships++
+		}
+
+		return true
+	})
+	lst := make([]*NamespaceStats, 0, len(stats))
+	for _, s := range stats {
+		lst = append(lst, s)
+	}
+	sort.Slice(lst, func(i, j int) bool {
+		return lst[i].Namespace < lst[j].Namespace
+	})
+
+	return lst
+}
+
+// SplitByNamespace splits the graph into a graph per namespace, sorted by
+// namespace. Every graph has the class terms of its namespace, all the other
+// terms, such as the properties, and the relationships between them. Its id
+// and IRI are the ones of the graph suffixed with the namespace and its
+// default namespace is the namespace. The terms outside any namespace are
+// duplicated in every graph, along with the relationships between them. The
+// relationships across namespaces are left out and returned separately.
+func SplitByNamespace(grph OboGraph) ([]OboGraph, []Relationship) {
+	nsm := classNamespaces(grph)
+	nsps := Namespaces(grph)
+	grphs := make(map[string]*graph)
+	parts := make([]*graph, 0, len(nsps))
+	lst := make([]OboGraph, 0, len(nsps))
+	for _, nsp := range nsps {
+		sgrph := newOboGraph(
+			namespaceMeta(grph, nsp),
+			grph.ID()+"-"+namespaceSlug(nsp),
+			grph.Label(),
+			grph.IRI()+"#"+namespaceSlug(nsp),
+		).(*graph)
+		grphs[nsp] = sgrph
+		parts = append(parts, sgrph)
+		lst = append(lst, sgrph)
+	}
+	grph.EachTerm(func(trm Term) bool {
+		if nsp, ok := nsm[trm.ID()]; ok {
+			grphs[nsp].AddTerm(trm)
+
+			return true
+		}
+		for _, sgrph := range parts {
+			sgrph.AddTerm(trm)
+		}
+
+		return true
+	})
+	cross := make([]Relationship, 0)
+	grph.EachRelationship(func(rel Relationship) bool {
+		snsp, sok := nsm[rel.Subject()]
+		onsp, ook := nsm[rel.Object()]
+		switch {
+		case sok && ook && snsp != onsp:
+			cross = append(cross, rel)
+		case sok || ook:
+			nsp := snsp
+			if !sok {
+				nsp = onsp
+			}
+			grphs[nsp].addEdge(rel)
+		default:
+			for _, sgrph := range parts {
+				sgrph.addEdge(rel)
+			}
+		}
+
+		return true
+	})
+
+	return lst, cross
+}
+
+// classNamespaces maps the class terms to their namespaces.
+func classNamespaces(grph OboGraph) map[NodeID]string {
+	nsm := make(map[NodeID]string)
+	grph.EachTerm(func(trm Term) bool {
+		if trm.RdfType() == "CLASS" {
+			nsm[trm.ID()] = TermNamespace(grph, trm)
+		}
+
+		return true
+	})
+
+	return nsm
+}
+
+// namespaceMeta copies the meta of the graph with the namespace as its
+// default namespace.
+func namespaceMeta(grph OboGraph, nsp string) *model.Meta {
+	opt := &model.MetaOptions{}
+	if grph.Meta() != nil {
+		opt = grph.Meta().Options()
+	}
+	props := make([]*model.BasicPropertyValue, 0, len(opt.BaseProps)+1)
+	for _, prop := range opt.BaseProps {
+		if !strings.HasSuffix(prop.Pred(), "#default-namespace") {
+			props = append(props, prop)
+		}
+	}
+	opt.BaseProps = append(props, model.NewBasicPropertyValue(defaultNamespacePred, nsp))
+
+	return model.NewMeta(opt)
+}
+
+func namespaceSlug(nsp string) string {
+	return strings.Join(strings.Fields(nsp), "_")
+}
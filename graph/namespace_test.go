@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func namespaceTerm(idn NodeID, lbl, nsp string) Term {
+	return NewTermWithMeta(
+		idn,
+		model.NewMeta(&model.MetaOptions{
+			BaseProps: []*model.BasicPropertyValue{
+				model.NewBasicPropertyValue(
+					"http://www.geneontology.org/formats/oboInOwl#hasOBONamespace", nsp,
+				),
+			},
+		}),
+		"CLASS", lbl, "http://purl.obolibrary.org/obo/"+string(idn),
+	)
+}
+
+func TestNamespaces(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	assert.Equal([]string{SEQ}, Namespaces(grph), "expect a single namespace")
+	nclass := len(grph.TermsByType("CLASS"))
+	assert.Len(TermsByNamespace(grph, SEQ), nclass, "expect the terms without namespace in the default one")
+
+	grph.AddTerm(namespaceTerm("SO_9000001", "test child", "test namespace"))
+	grph.AddTerm(namespaceTerm("SO_9000002", "test parent", "test namespace"))
+	assert.NoError(grph.AddRelationshipWithID("SO_9000002", "SO_9000001", "is_a"))
+	assert.NoError(grph.AddRelationshipWithID("SO_0000704", "SO_9000001", "is_a"))
+	assert.Equal([]string{SEQ, "test namespace"}, Namespaces(grph))
+	assert.Equal([]NodeID{"SO_9000001", "SO_9000002"}, termPipe(TermsByNamespace(grph, "test namespace")))
+
+	stats := NamespaceStatistics(grph)
+	assert.Len(stats, 2)
+	assert.Equal(SEQ, stats[0].Namespace)
+	assert.Equal(nclass, stats[0].Terms)
+	assert.Positive(stats[0].Deprecated, "expect deprecated terms")
+	assert.Zero(stats[0].CrossRelationships)
+	assert.Equal(&NamespaceStats{
+		Namespace:          "test namespace",
+		Terms:              2,
+		Relationships:      1,
+		CrossRelationships: 1,
+	}, stats[1])
+
+	grphs, cross := SplitByNamespace(grph)
+	assert.Len(grphs, 2, "expect a graph per namespace")
+	assert.Len(cross, 1, "expect the cross namespace relationship to be left out")
+	assert.Equal(NodeID("SO_9000001"), cross[0].Subject())
+	seq, tns := grphs[0], grphs[1]
+	assert.Equal("so.owl-sequence", seq.ID())
+	assert.Equal("so.owl-test_namespace", tns.ID(), "expect unique graph ids")
+	assert.Equal(grph.IRI()+"#test_namespace", tns.IRI())
+	assert.Equal("test namespace", tns.Meta().Namespace(), "expect the namespace as default namespace")
+	assert.Equal(grph.Meta().Version(), tns.Meta().Version())
+	assert.Len(seq.TermsByType("CLASS"), nclass)
+	assert.Len(tns.TermsByType("CLASS"), 2)
+	assert.Equal(len(grph.TermsByType("PROPERTY")), len(tns.TermsByType("PROPERTY")), "expect the properties in every graph")
+	assert.True(tns.ExistsTerm("is_a"), "expect the synthetic terms in every graph")
+	assert.Len(tns.Relationships(), 1+propertyRelationships(grph), "expect the relationships of the namespace and of the properties")
+	assert.False(seq.ExistsTerm("SO_9000001"))
+	assert.Equal([]NodeID{"SO_9000002"}, termPipe(tns.Parents("SO_9000001")))
+	assert.Equal(len(grph.Relationships())-1, len(seq.Relationships())+len(tns.Relationships())-propertyRelationships(grph))
+}
+
+func propertyRelationships(grph OboGraph) int {
+	cnt := 0
+	grph.EachRelationship(func(rel Relationship) bool {
+		if grph.GetTerm(rel.Subject()).RdfType() != "CLASS" && grph.GetTerm(rel.Object()).RdfType() != "CLASS" {
+			cnt++
+		}
+
+		return true
+	})
+
+	return cnt
+}
+
+func TestSplitByNamespaceFixture(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	dir, err := os.Getwd()
+	assert.NoError(err, "expect no error from getting current dir")
+	rdr, err := os.Open(filepath.Join(filepath.Dir(dir), "testdata", "namespaces.json"))
+	assert.NoError(err, "expect no error from opening the fixture")
+	defer rdr.Close()
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	assert.Equal([]string{"alpha", "beta"}, Namespaces(grph))
+	grphs, cross := SplitByNamespace(grph)
+	assert.Len(grphs, 2, "expect a graph per namespace")
+	assert.Equal(
+		[][3]NodeID{
+			{"TST_0000001", "TST_0000003", "is_a"},
+			{"TST_0000002", "TST_0000005", "BFO_0000050"},
+		},
+		relTriples(cross),
+		"expect the cross namespace relationships",
+	)
+	alpha, beta := grphs[0], grphs[1]
+	assert.Equal("tst.owl-alpha", alpha.ID())
+	assert.Equal("tst.owl-beta", beta.ID())
+	assert.Equal(
+		[][3]NodeID{
+			{"TST_0000001", "TST_0000002", "is_a"},
+		},
+		relTriples(classRelationships(alpha)),
+	)
+	assert.Equal(
+		[][3]NodeID{
+			{"TST_0000003", "TST_0000004", "is_a"},
+			{"TST_0000004", "TST_0000005", "BFO_0000050"},
+		},
+		relTriples(classRelationships(beta)),
+	)
+	for _, sgrph := range grphs {
+		assert.True(sgrph.ExistsTerm("BFO_0000050"), "expect the properties in every graph")
+		assert.Equal(
+			NodeID("inverseOf"),
+			sgrph.GetRelationship("BFO_0000050", "BFO_0000051").Predicate(),
+			"expect the relationships of the properties in every graph",
+		)
+	}
+	assert.False(alpha.ExistsTerm("TST_0000003"), "expect no term of another namespace")
+	assert.Empty(beta.Parents("TST_0000003"), "expect the cross namespace parent to be left out")
+}
+
+func classRelationships(grph OboGraph) []Relationship {
+	rels := make([]Relationship, 0)
+	grph.EachRelationship(func(rel Relationship) bool {
+		if grph.GetTerm(rel.Subject()).RdfType() == "CLASS" {
+			rels = append(rels, rel)
+		}
+
+		return true
+	})
+
+	return rels
+}
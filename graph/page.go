@@ -0,0 +1,30 @@
+package graph
+
+// TermsPage returns at most limit terms starting at offset, in the same
+// order as Terms. A non positive limit returns all the terms after the
+// offset.
+func TermsPage(grph OboGraph, offset, limit int) []Term {
+	return page(grph.Terms(), offset, limit)
+}
+
+// RelationshipsPage returns at most limit relationships starting at offset,
+// in the same order as Relationships. A non positive limit returns all the
+// relationships after the offset.
+func RelationshipsPage(grph OboGraph, offset, limit int) []Relationship {
+	return page(grph.Relationships(), offset, limit)
+}
+
+func page[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return make([]T, 0)
+	}
+	end := len(items)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	return items[offset:end]
+}
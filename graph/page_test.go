@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphStableOrder(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	builds := make([]OboGraph, 0)
+	for i := 0; i < 2; i++ {
+		rdr, err := getReader()
+		assert.NoError(err, "expect no error from the reader")
+		grph, err := BuildGraph(rdr)
+		assert.NoError(err, "expect no error from building the graph")
+		builds = append(builds, grph)
+	}
+	first, second := builds[0], builds[1]
+	assert.Equal(termPipe(first.Terms()), termPipe(second.Terms()), "expect same term order")
+	assert.Equal(
+		[]NodeID{"is_a", "subPropertyOf", "inverseOf", "type", "topObjectProperty"},
+		termPipe(first.Terms()[:5]),
+		"expect terms in the order they were added",
+	)
+	assert.Equal(
+		termPipe(first.TermsByType("PROPERTY")),
+		termPipe(second.TermsByType("PROPERTY")),
+		"expect same filtered term order",
+	)
+	frels, srels := first.Relationships(), second.Relationships()
+	assert.Len(srels, len(frels), "expect same number of relationships")
+	for i := range frels {
+		assert.Equal(frels[i].Object(), srels[i].Object(), "expect same relationship order")
+		assert.Equal(frels[i].Subject(), srels[i].Subject(), "expect same relationship order")
+	}
+	for _, id := range []NodeID{"SO_0001217", "SO_0000704", "SO_0000110"} {
+		assert.Equal(termPipe(first.Children(id)), termPipe(second.Children(id)))
+		assert.Equal(termPipe(first.Parents(id)), termPipe(second.Parents(id)))
+		assert.Equal(termPipe(first.Descendents(id)), termPipe(second.Descendents(id)))
+	}
+	// an overwritten term keeps its position
+	first.AddTerm(first.GetTerm("type"))
+	assert.Equal(NodeID("type"), first.Terms()[3].ID(), "expect overwritten term in place")
+}
+
+func TestGraphPagination(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	terms := grph.Terms()
+	pg := TermsPage(grph, 5, 10)
+	assert.Len(pg, 10, "expect a page of 10 terms")
+	assert.Equal(termPipe(terms[5:15]), termPipe(pg), "expect page to follow term order")
+	assert.Len(TermsPage(grph, len(terms)-3, 10), 3, "expect a short last page")
+	assert.Empty(TermsPage(grph, len(terms), 10), "expect empty page past the end")
+	assert.Len(TermsPage(grph, -1, 0), len(terms), "expect all terms without limit")
+	rels := grph.Relationships()
+	rpg := RelationshipsPage(grph, 100, 20)
+	assert.Len(rpg, 20, "expect a page of 20 relationships")
+	assert.Equal(rels[100].Subject(), rpg[0].Subject(), "expect page to follow relationship order")
+}
@@ -14,17 +14,64 @@ import (
 	"github.com/dictyBase/go-obograph/schema"
 )
 
-// BuildGraph builds an in memory graph from JSON-encoded obograph reader.
-func BuildGraph(r io.Reader) (OboGraph, error) {
+// syntheticTerms are the owl concepts that are added as obo terms to every
+// graph.
+var syntheticTerms = map[NodeID]bool{
+	"is_a":              true,
+	"subPropertyOf":     true,
+	"inverseOf":         true,
+	"type":              true,
+	"topObjectProperty": true,
+}
+
+// Layout is the in memory representation of the graphs built by
+// BuildGraph.
+type Layout int
+
+const (
+	// MapLayout keys the terms and their relationships by id in maps. It is
+	// the default layout, as it is cheap to modify.
+	MapLayout Layout = iota
+	// CompactLayout interns the term ids into dense integers with CSR
+	// adjacency arrays, as NewCompactGraph. It uses a fraction of the memory
+	// and is faster to traverse, but removing terms or relationships is
+	// linear to the size of the graph.
+	CompactLayout
+)
+
+// BuildGraph builds an in memory graph from JSON-encoded obograph reader. The
+// graph has the map layout unless another layout is given. On error, an
+// empty graph of the layout is returned.
+func BuildGraph(r io.Reader, layout ...Layout) (OboGraph, error) {
+	create := newOboGraph
+	if len(layout) > 0 && layout[0] == CompactLayout {
+		create = newCompactGraph
+	}
+	grph, err := readGraph(r, create)
+	if err != nil {
+		return create(nil, "", "", ""), err
+	}
+
+	return grph, nil
+}
+
+// readGraph decodes the first obograph of the reader into an empty graph
+// created by the given constructor, adding the terms and the relationships
+// in the order of the JSON.
+func readGraph(
+	r io.Reader,
+	create func(*model.Meta, string, string, string) OboGraph,
+) (OboGraph, error) {
 	ojs := &schema.OboJSON{}
 	err := json.NewDecoder(r).Decode(ojs)
 	if err != nil {
-		return &graph{}, fmt.Errorf("error in decoding obograph json %s", err)
+		return nil, fmt.Errorf("error in decoding obograph json %s", err)
 	}
 	ogf := ojs.Graphs[0]
-	grph := newOboGraph(
+	grph := create(
 		model.NewMeta(buildGraphMeta(ogf.Meta)),
 		internal.ExtractID(ogf.ID),
+		ogf.Lbl,
 		ogf.ID,
 	)
 	// Add the various owl concepts as obo terms
@@ -43,7 +90,7 @@ func BuildGraph(r io.Reader) (OboGraph, error) {
 			NodeID(internal.ExtractID(je.Pred)),
 		)
 		if err != nil {
-			return &graph{}, fmt.Errorf("error in adding relationship %s", err)
+			return nil, fmt.Errorf("error in adding relationship %s", err)
 		}
 	}
 
@@ -157,7 +204,7 @@ func buildBaseMeta(jsm *schema.JSONMeta) *model.MetaOptions {
 	pval := make([]*model.BasicPropertyValue, 0)
 	if jsm.BasicPropertyValues != nil && len(jsm.BasicPropertyValues) > 0 {
 		for _, bp := range jsm.BasicPropertyValues {
-			pval = append(pval, model.NewBasicPropertyValue(bp.Pred, bp.Val))
+			pval = append(pval, model.NewTypedPropertyValue(bp.Pred, bp.Val, bp.ValType, bp.Lang))
 		}
 		mop.BaseProps = pval
 	}
@@ -0,0 +1,129 @@
+package graph
+
+import "fmt"
+
+// RedundantRelationships returns the relationships that are implied by a
+// longer path of relationships with the same predicate, for example an
+// asserted A is_a C when A is_a B and B is_a C are also present. Every given
+// predicate is analysed separately, all predicates of the graph are analysed
+// when none is given. A *CycleError is returned if the relationships of any
+// analysed predicate contain a cycle.
+func RedundantRelationships(grph OboGraph, preds ...NodeID) ([]Relationship, error) {
+	if len(preds) == 0 {
+		preds = relationshipPredicates(grph)
+	}
+	redundant := make([]Relationship, 0)
+	for _, pred := range preds {
+		if cycles := DetectCycles(grph, pred); len(cycles) > 0 {
+			return redundant, &CycleError{Cycles: cycles}
+		}
+		anc := make(map[NodeID]map[NodeID]bool)
+		for _, trm := range grph.Terms() {
+			parents := ParentsByPredicate(grph, trm.ID(), pred)
+			if len(parents) < 2 {
+				continue
+			}
+			for _, parent := range parents {
+				if impliedParent(grph, parent.ID(), parents, pred, anc) {
+					redundant = append(
+						redundant,
+						grph.GetRelationship(parent.ID(), trm.ID()),
+					)
+				}
+			}
+		}
+	}
+
+	return redundant, nil
+}
+
+// TransitiveReduction returns a copy of the graph without the redundant
+// relationships of the given predicates, or of all predicates when none is
+// given. The copy has the id, label, IRI and meta of the graph, whose terms
+// are shared with the copy.
+func TransitiveReduction(grph OboGraph, preds ...NodeID) (OboGraph, error) {
+	redundant, err := RedundantRelationships(grph, preds...)
+	if err != nil {
+		return &graph{}, err
+	}
+	skip := make(map[[2]NodeID]bool)
+	for _, rel := range redundant {
+		skip[[2]NodeID{rel.Object(), rel.Subject()}] = true
+	}
+	rgrph := newOboGraph(grph.Meta(), grph.ID(), grph.Label(), grph.IRI())
+	for _, trm := range grph.Terms() {
+		rgrph.AddTerm(trm)
+	}
+	for _, rel := range grph.Relationships() {
+		if skip[[2]NodeID{rel.Object(), rel.Subject()}] {
+			continue
+		}
+		err := rgrph.AddRelationshipWithID(
+			rel.Object(),
+			rel.Subject(),
+			rel.Predicate(),
+		)
+		if err != nil {
+			return &graph{}, fmt.Errorf("error in adding relationship %s", err)
+		}
+	}
+
+	return rgrph, nil
+}
+
+// impliedParent checks if a parent is also an ancestor of any other parent.
+func impliedParent(
+	grph OboGraph,
+	pid NodeID,
+	parents []Term,
+	pred NodeID,
+	anc map[NodeID]map[NodeID]bool,
+) bool {
+	for _, other := range parents {
+		if other.ID() == pid {
+			continue
+		}
+		if ancestorSet(grph, other.ID(), pred, anc)[pid] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ancestorSet returns the memoized ancestors of an acyclic predicate.
+func ancestorSet(
+	grph OboGraph,
+	idn NodeID,
+	pred NodeID,
+	anc map[NodeID]map[NodeID]bool,
+) map[NodeID]bool {
+	if set, ok := anc[idn]; ok {
+		return set
+	}
+	set := make(map[NodeID]bool)
+	for _, parent := range ParentsByPredicate(grph, idn, pred) {
+		set[parent.ID()] = true
+		for id := range ancestorSet(grph, parent.ID(), pred, anc) {
+			set[id] = true
+		}
+	}
+	anc[idn] = set
+
+	return set
+}
+
+// relationshipPredicates returns the distinct predicates of all
+// relationships.
+func relationshipPredicates(grph OboGraph) []NodeID {
+	preds := make([]NodeID, 0)
+	seen := make(map[NodeID]bool)
+	for _, rel := range grph.Relationships() {
+		if !seen[rel.Predicate()] {
+			seen[rel.Predicate()] = true
+			preds = append(preds, rel.Predicate())
+		}
+	}
+
+	return preds
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedundantRelationships(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := newOboGraph(model.NewMeta(&model.MetaOptions{}), "test", "test graph", "test")
+	grph.AddTerm(buildIsaTerm())
+	grph.AddTerm(NewTerm("part_of", "PROPERTY", "part_of", "part_of"))
+	for _, id := range []NodeID{"A", "B", "C", "D"} {
+		grph.AddTerm(NewTerm(id, "CLASS", string(id), string(id)))
+	}
+	for _, rel := range [][]NodeID{
+		{"B", "A", "is_a"},
+		{"C", "B", "is_a"},
+		{"C", "A", "is_a"},
+		{"B", "D", "is_a"},
+		{"C", "D", "part_of"},
+	} {
+		assert.NoError(grph.AddRelationshipWithID(rel[0], rel[1], rel[2]))
+	}
+	redundant, err := RedundantRelationships(grph)
+	assert.NoError(err, "expect no error from acyclic graph")
+	assert.Len(redundant, 1, "expect one redundant relationship")
+	assert.Equal(NodeID("C"), redundant[0].Object())
+	assert.Equal(NodeID("A"), redundant[0].Subject())
+	rgrph, err := TransitiveReduction(grph)
+	assert.NoError(err, "expect no error from reduction")
+	assert.Len(rgrph.Relationships(), 4, "expect one relationship to be removed")
+	assert.Equal("test graph", rgrph.Label(), "expect the label of the graph")
+	assert.Nil(rgrph.GetRelationship("C", "A"), "expect C to A to be removed")
+	assert.ElementsMatch(
+		termPipe(grph.Ancestors("A")),
+		termPipe(rgrph.Ancestors("A")),
+		"expect the same ancestors after reduction",
+	)
+	assert.NoError(grph.AddRelationshipWithID("A", "C", "is_a"))
+	_, err = TransitiveReduction(grph)
+	var cerr *CycleError
+	assert.True(errors.As(err, &cerr), "expect cycle error")
+}
+
+func TestTransitiveReductionOntology(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	isa := NodeID("is_a")
+	redundant, err := RedundantRelationships(grph, isa)
+	assert.NoError(err, "expect no error from finding redundant relationships")
+	rgrph, err := TransitiveReduction(grph, isa)
+	assert.NoError(err, "expect no error from reduction")
+	assert.Len(
+		rgrph.Relationships(),
+		len(grph.Relationships())-len(redundant),
+		"expect redundant relationships to be removed",
+	)
+	for _, rel := range redundant {
+		assert.Equal(isa, rel.Predicate(), "expect only is_a relationships")
+		assert.Contains(
+			termPipe(AncestorsByPredicate(rgrph, rel.Subject(), isa)),
+			rel.Object(),
+			"expect removed parent to stay an ancestor",
+		)
+	}
+}
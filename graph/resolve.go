@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/internal"
+)
+
+// ResolveStatus tells how an id was resolved.
+type ResolveStatus int
+
+const (
+	// Current is a term that is not obsolete.
+	Current ResolveStatus = iota
+	// Replaced is an obsolete term that was replaced by a current term.
+	Replaced
+	// Ambiguous is an obsolete term without a single replacement, the
+	// candidates are the terms to consider or the multiple replacements.
+	Ambiguous
+	// Obsolete is an obsolete term without any replacement or candidate.
+	Obsolete
+)
+
+// Resolution is the outcome of resolving an id.
+type Resolution struct {
+	Status ResolveStatus
+	// ID is the id of the current term for Current and Replaced, otherwise
+	// the id of the last obsolete term
+	ID NodeID
+	// Path are the ids of the terms followed, starting with the primary id
+	// of the resolved id
+	Path []NodeID
+	// Candidates are the ids of the possible replacements of an Ambiguous
+	// term
+	Candidates []NodeID
+}
+
+// IDIndex looks up terms by their primary or alternative ids, given as node
+// ids, CURIEs or IRIs. The index reflects the alternative ids of the graph
+// at the time it was built.
+type IDIndex struct {
+	grph OboGraph
+	alt  map[NodeID]NodeID
+}
+
+// NewIDIndex indexes the alternative ids of the terms of the graph.
+func NewIDIndex(grph OboGraph) *IDIndex {
+	idx := &IDIndex{grph: grph, alt: make(map[NodeID]NodeID)}
+	grph.EachTerm(func(trm Term) bool {
+		if !trm.HasMeta() {
+			return true
+		}
+		for _, val := range trm.Meta().AltIDs() {
+			idx.alt[NormalizeID(val)] = trm.ID()
+		}
+
+		return true
+	})
+
+	return idx
+}
+
+// NormalizeID converts an IRI or a CURIE, for example SO:0000704, to a node
+// id.
+func NormalizeID(val string) NodeID {
+	return NodeID(strings.Replace(internal.ExtractID(val), ":", "_", 1))
+}
+
+// PrimaryID returns the primary id of a term from any of its ids.
+func (i *IDIndex) PrimaryID(val string) (NodeID, bool) {
+	idn := NormalizeID(val)
+	if i.grph.ExistsTerm(idn) {
+		return idn, true
+	}
+	pid, ok := i.alt[idn]
+
+	return pid, ok
+}
+
+// ExistsTerm checks for existence of a term by any of its ids.
+func (i *IDIndex) ExistsTerm(val string) bool {
+	_, ok := i.PrimaryID(val)
+
+	return ok
+}
+
+// GetTerm fetches a term by any of its ids.
+func (i *IDIndex) GetTerm(val string) Term {
+	pid, ok := i.PrimaryID(val)
+	if !ok {
+		return nil
+	}
+
+	return i.grph.GetTerm(pid)
+}
+
+// AltIDs returns the alternative ids of a term.
+func (i *IDIndex) AltIDs(idn NodeID) []NodeID {
+	ids := make([]NodeID, 0)
+	trm := i.grph.GetTerm(idn)
+	if trm == nil || !trm.HasMeta() {
+		return ids
+	}
+	for _, val := range trm.Meta().AltIDs() {
+		ids = append(ids, NormalizeID(val))
+	}
+
+	return ids
+}
+
+// Resolve finds the current term for any id of a term, following the
+// replaced_by chain of obsolete terms. An obsolete term without a single
+// replacement is Ambiguous when it has terms to consider or several
+// replacements, otherwise it stays Obsolete.
+func (i *IDIndex) Resolve(val string) (*Resolution, error) {
+	pid, ok := i.PrimaryID(val)
+	if !ok {
+		return nil, fmt.Errorf("node id %s does not exist", NormalizeID(val))
+	}
+	res := &Resolution{ID: pid, Path: []NodeID{pid}}
+	visited := map[NodeID]bool{pid: true}
+	for {
+		trm := i.grph.GetTerm(res.ID)
+		if !trm.IsDeprecated() {
+			if len(res.Path) > 1 {
+				res.Status = Replaced
+			}
+
+			return res, nil
+		}
+		replacedBy := i.references(trm.Meta().ReplacedBy())
+		switch len(replacedBy) {
+		case 0:
+			res.Candidates = i.references(trm.Meta().Consider())
+			res.Status = Obsolete
+			if len(res.Candidates) > 0 {
+				res.Status = Ambiguous
+			}
+
+			return res, nil
+		case 1:
+		default:
+			res.Candidates = replacedBy
+			res.Status = Ambiguous
+
+			return res, nil
+		}
+		nxt := replacedBy[0]
+		if visited[nxt] {
+			return res, fmt.Errorf(
+				"replacement of node id %s leads back to %s",
+				res.ID, nxt,
+			)
+		}
+		visited[nxt] = true
+		res.ID = nxt
+		res.Path = append(res.Path, nxt)
+	}
+}
+
+// references returns the primary ids of the existing terms that the values
+// refer to.
+func (i *IDIndex) references(vals []string) []NodeID {
+	ids := make([]NodeID, 0)
+	for _, val := range vals {
+		if pid, ok := i.PrimaryID(val); ok {
+			ids = append(ids, pid)
+		}
+	}
+
+	return ids
+}
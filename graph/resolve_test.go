@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDIndex(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	trm := grph.GetTerm("SO_0000159")
+	opt := trm.Meta().Options()
+	opt.BaseProps = append(opt.BaseProps, model.NewBasicPropertyValue(model.AltIDIRI, "SO:9000033"))
+	assert.NoError(grph.UpdateTerm(NewTermWithMeta(trm.ID(), model.NewMeta(opt), trm.RdfType(), trm.Label(), trm.IRI())))
+	idx := NewIDIndex(grph)
+	assert.Equal(NodeID("SO_0000704"), NormalizeID("SO:0000704"))
+	assert.Equal(NodeID("SO_0000704"), NormalizeID("http://purl.obolibrary.org/obo/SO_0000704"))
+	for _, val := range []string{"SO:9000033", "SO_9000033", "http://purl.obolibrary.org/obo/SO_9000033"} {
+		pid, ok := idx.PrimaryID(val)
+		assert.Truef(ok, "expect %s to be found", val)
+		assert.Equal(NodeID("SO_0000159"), pid, "expect primary id")
+		assert.Equal(NodeID("SO_0000159"), idx.GetTerm(val).ID(), "expect primary term")
+	}
+	pid, ok := idx.PrimaryID("SO:1000033")
+	assert.True(ok, "expect alternative id with its own obsolete term")
+	assert.Equal(NodeID("SO_1000033"), pid, "expect the existing term to win")
+	assert.Contains(idx.AltIDs("SO_0000159"), NodeID("SO_1000033"), "expect alternative id")
+	assert.True(idx.ExistsTerm("SO:0000704"), "expect the term from CURIE")
+	assert.False(idx.ExistsTerm("SO:9999999"), "expect no term")
+	assert.Nil(idx.GetTerm("SO:9999999"), "expect no term")
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	idx := NewIDIndex(grph)
+
+	res, err := idx.Resolve("SO:0000704")
+	assert.NoError(err, "expect no error from resolving a current term")
+	assert.Equal(Current, res.Status)
+	assert.Equal(NodeID("SO_0000704"), res.ID)
+
+	res, err = idx.Resolve("SO:1000033")
+	assert.NoError(err, "expect no error from resolving a merged term")
+	assert.Equal(Replaced, res.Status)
+	assert.Equal(NodeID("SO_0000159"), res.ID, "expect term it was merged into")
+
+	res, err = idx.Resolve("SO_0005839")
+	assert.NoError(err, "expect no error from resolving IRI replacement")
+	assert.Equal(Replaced, res.Status)
+	assert.Equal(NodeID("SO_0000403"), res.ID)
+	assert.Equal([]NodeID{"SO_0005839", "SO_0000403"}, res.Path)
+
+	res, err = idx.Resolve("SO_0001020")
+	assert.NoError(err, "expect no error from resolving CURIE replacement")
+	assert.Equal(NodeID("SO_0001563"), res.ID)
+
+	res, err = idx.Resolve("SO_1000091")
+	assert.NoError(err, "expect no error from resolving a chain")
+	assert.Equal([]NodeID{"SO_1000091", "SO_1000088"}, res.Path[:2], "expect replacement chain")
+	assert.False(grph.GetTerm(res.ID).IsDeprecated() && res.Status == Replaced)
+
+	res, err = idx.Resolve("SO_1000092")
+	assert.NoError(err, "expect no error from resolving a term to consider")
+	assert.Equal(Ambiguous, res.Status)
+	assert.Equal([]NodeID{"SO_0001539"}, res.Candidates)
+
+	res, err = idx.Resolve("SO_0000160")
+	assert.NoError(err, "expect no error from resolving an obsolete term")
+	assert.Equal(Obsolete, res.Status)
+	assert.Empty(res.Candidates, "expect no candidate")
+
+	_, err = idx.Resolve("SO:9999999")
+	assert.Error(err, "expect error for unknown id")
+
+	assert.NoError(grph.ObsoleteTerm("SO_0000704", "SO_0001217"))
+	assert.NoError(grph.ObsoleteTerm("SO_0001217", "SO_0000704"))
+	_, err = NewIDIndex(grph).Resolve("SO_0000704")
+	assert.Error(err, "expect error for replacement cycle")
+}
@@ -0,0 +1,471 @@
+package graph
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// SnapshotVersion is the version of the binary snapshot format written by
+// SaveSnapshot. Snapshots of any other version cannot be loaded.
+const SnapshotVersion = 3
+
+const snapshotMagic = "obograph-snapshot"
+
+// SnapshotHeader describes a binary snapshot of a graph.
+type SnapshotHeader struct {
+	// Version is the version of the snapshot format
+	Version int
+	// Checksum identifies the source the graph was built from, usually the
+	// FileChecksum of the JSON file
+	Checksum string
+}
+
+type snapshotHeader struct {
+	Magic string
+	SnapshotHeader
+}
+
+type snapshotGraph struct {
+	ID    string
+	IRI   string
+	Label string
+	Meta  *snapshotMeta
+	Terms []*snapshotTerm
+	// relationships as indices of Terms, in the order of the graph
+	Relationships []snapshotRelationship
+	// adjacency of the terms as indices of Relationships, the children of
+	// term i are at Down[DownOff[i]:DownOff[i+1]] and its parents at
+	// Up[UpOff[i]:UpOff[i+1]]
+	DownOff []int32
+	Down    []int32
+	UpOff   []int32
+	Up      []int32
+}
+
+type snapshotTerm struct {
+	ID      string
+	RdfType string
+	Label   string
+	IRI     string
+	Meta    *snapshotMeta
+}
+
+type snapshotRelationship struct {
+	Obj  int32
+	Subj int32
+	Pred int32
+}
+
+type snapshotMeta struct {
+	Definition *snapshotProperty
+	BaseProps  []*snapshotProperty
+	Synonyms   []*snapshotProperty
+	Xrefs      []*snapshotProperty
+	Comments   []string
+	Subsets    []string
+	Version    string
+	Deprecated bool
+}
+
+type snapshotProperty struct {
+	Pred    string
+	Val     string
+	Xrefs   []string
+	ValType string
+	Lang    string
+}
+
+// FileChecksum returns the hex encoded SHA-256 checksum of a file, to be
+// recorded in a snapshot of the graph built from it.
+func FileChecksum(file string) (string, error) {
+	fhr, err := os.Open(file)
+	if err != nil {
+		return "", fmt.Errorf("error in opening file %s %s", file, err)
+	}
+	defer fhr.Close()
+	hsh := sha256.New()
+	if _, err := io.Copy(hsh, fhr); err != nil {
+		return "", fmt.Errorf("error in reading file %s %s", file, err)
+	}
+
+	return hex.EncodeToString(hsh.Sum(nil)), nil
+}
+
+// SaveSnapshot writes a versioned binary snapshot of the graph along with
+// the checksum of its source. The snapshot has the terms with their meta, the
+// relationships and the adjacency indexes of the graph, so that LoadSnapshot
+// restores the indexes as they are instead of adding the relationships one
+// by one.
+func SaveSnapshot(w io.Writer, grph OboGraph, checksum string) error {
+	enc := gob.NewEncoder(w)
+	err := enc.Encode(&snapshotHeader{
+		Magic: snapshotMagic,
+		SnapshotHeader: SnapshotHeader{
+			Version:  SnapshotVersion,
+			Checksum: checksum,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error in encoding snapshot header %s", err)
+	}
+	if err := enc.Encode(toSnapshot(grph)); err != nil {
+		return fmt.Errorf("error in encoding snapshot %s", err)
+	}
+
+	return nil
+}
+
+// ReadSnapshotHeader reads only the header of a binary snapshot.
+func ReadSnapshotHeader(r io.Reader) (*SnapshotHeader, error) {
+	return readSnapshotHeader(gob.NewDecoder(r))
+}
+
+// LoadSnapshot reads a graph from a binary snapshot written by SaveSnapshot.
+func LoadSnapshot(r io.Reader) (OboGraph, *SnapshotHeader, error) {
+	dec := gob.NewDecoder(r)
+	hdr, err := readSnapshotHeader(dec)
+	if err != nil {
+		return &graph{}, hdr, err
+	}
+	sgr := &snapshotGraph{}
+	if err := dec.Decode(sgr); err != nil {
+		return &graph{}, hdr, fmt.Errorf("error in decoding snapshot %s", err)
+	}
+	grph, err := fromSnapshot(sgr)
+	if err != nil {
+		return &graph{}, hdr, err
+	}
+
+	return grph, hdr, nil
+}
+
+// LoadOrBuildGraph reads the graph from the snapshot file if it was built
+// from the same JSON file, otherwise it builds the graph from the JSON file
+// and writes a fresh snapshot for the next call.
+func LoadOrBuildGraph(jsonFile, snapshotFile string) (OboGraph, error) {
+	checksum, err := FileChecksum(jsonFile)
+	if err != nil {
+		return &graph{}, err
+	}
+	if grph, ok := loadMatchingSnapshot(snapshotFile, checksum); ok {
+		return grph, nil
+	}
+	jsr, err := os.Open(jsonFile)
+	if err != nil {
+		return &graph{}, fmt.Errorf("error in opening file %s %s", jsonFile, err)
+	}
+	defer jsr.Close()
+	grph, err := BuildGraph(jsr)
+	if err != nil {
+		return grph, err
+	}
+	if err := writeSnapshotFile(snapshotFile, grph, checksum); err != nil {
+		return grph, err
+	}
+
+	return grph, nil
+}
+
+func loadMatchingSnapshot(file, checksum string) (OboGraph, bool) {
+	fhr, err := os.Open(file)
+	if err != nil {
+		return nil, false
+	}
+	defer fhr.Close()
+	grph, hdr, err := LoadSnapshot(bufio.NewReader(fhr))
+	if err != nil || hdr.Checksum != checksum {
+		return nil, false
+	}
+
+	return grph, true
+}
+
+// writeSnapshotFile writes the snapshot into a temporary file that replaces
+// the existing one only when complete.
+func writeSnapshotFile(file string, grph OboGraph, checksum string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return fmt.Errorf("error in creating snapshot file %s", err)
+	}
+	defer os.Remove(tmp.Name())
+	bfw := bufio.NewWriter(tmp)
+	if err := SaveSnapshot(bfw, grph, checksum); err != nil {
+		tmp.Close()
+
+		return err
+	}
+	if err := bfw.Flush(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error in writing snapshot file %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error in closing snapshot file %s", err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("error in renaming snapshot file %s", err)
+	}
+
+	return nil
+}
+
+func readSnapshotHeader(dec *gob.Decoder) (*SnapshotHeader, error) {
+	hdr := &snapshotHeader{}
+	if err := dec.Decode(hdr); err != nil {
+		return &hdr.SnapshotHeader, fmt.Errorf("error in decoding snapshot header %s", err)
+	}
+	if hdr.Magic != snapshotMagic {
+		return &hdr.SnapshotHeader, errors.New("not an obograph snapshot")
+	}
+	if hdr.Version != SnapshotVersion {
+		return &hdr.SnapshotHeader, fmt.Errorf(
+			"snapshot version %d is not supported, expected %d",
+			hdr.Version, SnapshotVersion,
+		)
+	}
+
+	return &hdr.SnapshotHeader, nil
+}
+
+func toSnapshot(grph OboGraph) *snapshotGraph {
+	sgr := &snapshotGraph{
+		ID:    grph.ID(),
+		IRI:   grph.IRI(),
+		Label: grph.Label(),
+		Meta:  toSnapshotMeta(grph.Meta()),
+	}
+	index := make(map[NodeID]int32)
+	grph.EachTerm(func(trm Term) bool {
+		index[trm.ID()] = int32(len(sgr.Terms))
+		strm := &snapshotTerm{
+			ID:      string(trm.ID()),
+			RdfType: trm.RdfType(),
+			Label:   trm.Label(),
+			IRI:     trm.IRI(),
+		}
+		if trm.HasMeta() {
+			strm.Meta = toSnapshotMeta(trm.Meta())
+		}
+		sgr.Terms = append(sgr.Terms, strm)
+
+		return true
+	})
+	objs, subjs := make([]int32, 0), make([]int32, 0)
+	grph.EachRelationship(func(rel Relationship) bool {
+		srel := snapshotRelationship{
+			Obj:  index[rel.Object()],
+			Subj: index[rel.Subject()],
+			Pred: index[rel.Predicate()],
+		}
+		sgr.Relationships = append(sgr.Relationships, srel)
+		objs = append(objs, srel.Obj)
+		subjs = append(subjs, srel.Subj)
+
+		return true
+	})
+	// the relationships are in the order of the terms, so are the
+	// neighbours of every term
+	sgr.DownOff, sgr.Down = buildCSR(len(sgr.Terms), objs)
+	sgr.UpOff, sgr.Up = buildCSR(len(sgr.Terms), subjs)
+
+	return sgr
+}
+
+func fromSnapshot(sgr *snapshotGraph) (OboGraph, error) {
+	grph := newOboGraph(fromSnapshotMeta(sgr.Meta), sgr.ID, sgr.Label, sgr.IRI).(*graph)
+	ids := make([]NodeID, 0, len(sgr.Terms))
+	for _, strm := range sgr.Terms {
+		ids = append(ids, NodeID(strm.ID))
+		if strm.Meta != nil {
+			grph.setTerm(NewTermWithMeta(
+				NodeID(strm.ID),
+				fromSnapshotMeta(strm.Meta),
+				strm.RdfType,
+				strm.Label,
+				strm.IRI,
+			))
+
+			continue
+		}
+		grph.setTerm(NewTerm(NodeID(strm.ID), strm.RdfType, strm.Label, strm.IRI))
+	}
+	size := int32(len(sgr.Terms))
+	rels := make([]Relationship, 0, len(sgr.Relationships))
+	for _, rel := range sgr.Relationships {
+		if !validIndex(rel.Obj, size) || !validIndex(rel.Subj, size) || !validIndex(rel.Pred, size) {
+			return grph, errors.New("snapshot relationship refers to unknown term")
+		}
+		rels = append(rels, NewRelationship(ids[rel.Obj], ids[rel.Subj], ids[rel.Pred]))
+	}
+	if !validOffsets(sgr.DownOff, size, sgr.Down, rels) || !validOffsets(sgr.UpOff, size, sgr.Up, rels) {
+		return grph, errors.New("snapshot adjacency does not match its relationships")
+	}
+	for tdx, idn := range ids {
+		down, err := snapshotAdjacency(
+			idn, sgr.Down[sgr.DownOff[tdx]:sgr.DownOff[tdx+1]], rels,
+			Relationship.Object, Relationship.Subject,
+		)
+		if err != nil {
+			return grph, err
+		}
+		up, err := snapshotAdjacency(
+			idn, sgr.Up[sgr.UpOff[tdx]:sgr.UpOff[tdx+1]], rels,
+			Relationship.Subject, Relationship.Object,
+		)
+		if err != nil {
+			return grph, err
+		}
+		if down != nil {
+			grph.edgesDown[idn] = down
+		}
+		if up != nil {
+			grph.edgesUp[idn] = up
+		}
+	}
+	for _, rel := range rels {
+		if _, ok := grph.edgesPred[rel.Predicate()]; !ok {
+			grph.edgesPred[rel.Predicate()] = make(map[[2]NodeID]bool)
+		}
+		grph.edgesPred[rel.Predicate()][[2]NodeID{rel.Object(), rel.Subject()}] = true
+	}
+
+	return grph, nil
+}
+
+// snapshotAdjacency restores the adjacency of a term from the indices of its
+// relationships, the term has to be their end and the neighbours, the other
+// end, must be unique.
+func snapshotAdjacency(
+	idn NodeID,
+	edxs []int32,
+	rels []Relationship,
+	end, other func(Relationship) NodeID,
+) (*adjacency, error) {
+	if len(edxs) == 0 {
+		return nil, nil
+	}
+	adj := &adjacency{
+		ids:  make([]NodeID, 0, len(edxs)),
+		rels: make(map[NodeID]Relationship, len(edxs)),
+	}
+	for _, edx := range edxs {
+		rel := rels[edx]
+		nid := other(rel)
+		if _, ok := adj.rels[nid]; ok || end(rel) != idn {
+			return adj, fmt.Errorf("snapshot adjacency of %s does not match its relationships", idn)
+		}
+		adj.ids = append(adj.ids, nid)
+		adj.rels[nid] = rel
+	}
+
+	return adj, nil
+}
+
+func validIndex(idx, size int32) bool {
+	return idx >= 0 && idx < size
+}
+
+// validOffsets checks that the offsets split the adjacency into one range for
+// every term and that the adjacency has an entry for every relationship.
+func validOffsets(off []int32, size int32, adj []int32, rels []Relationship) bool {
+	if len(off) != int(size)+1 || off[0] != 0 || int(off[size]) != len(adj) || len(adj) != len(rels) {
+		return false
+	}
+	for i := int32(0); i < size; i++ {
+		if off[i] > off[i+1] {
+			return false
+		}
+	}
+	for _, edx := range adj {
+		if !validIndex(edx, int32(len(rels))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toSnapshotMeta(mta *model.Meta) *snapshotMeta {
+	if mta == nil {
+		return nil
+	}
+	opt := mta.Options()
+	smt := &snapshotMeta{
+		Comments:   opt.Comments,
+		Subsets:    opt.Subsets,
+		Version:    opt.Version,
+		Deprecated: opt.Deprecated,
+	}
+	if opt.Definition != nil {
+		smt.Definition = &snapshotProperty{
+			Val:   opt.Definition.Value(),
+			Xrefs: opt.Definition.Xrefs(),
+		}
+	}
+	for _, prop := range opt.BaseProps {
+		smt.BaseProps = append(smt.BaseProps, &snapshotProperty{
+			Pred:    prop.Pred(),
+			Val:     prop.Value(),
+			ValType: prop.ValType(),
+			Lang:    prop.Lang(),
+		})
+	}
+	for _, syn := range opt.Synonyms {
+		smt.Synonyms = append(smt.Synonyms, &snapshotProperty{
+			Pred:  syn.Pred(),
+			Val:   syn.Value(),
+			Xrefs: syn.Xrefs(),
+		})
+	}
+	for _, xref := range opt.Xrefs {
+		smt.Xrefs = append(smt.Xrefs, &snapshotProperty{Val: xref.Value()})
+	}
+
+	return smt
+}
+
+func fromSnapshotMeta(smt *snapshotMeta) *model.Meta {
+	if smt == nil {
+		return nil
+	}
+	opt := &model.MetaOptions{
+		Comments:   smt.Comments,
+		Subsets:    smt.Subsets,
+		Version:    smt.Version,
+		Deprecated: smt.Deprecated,
+	}
+	if smt.Definition != nil {
+		opt.Definition = model.NewDefinition(smt.Definition.Val, smt.Definition.Xrefs)
+	}
+	for _, prop := range smt.BaseProps {
+		opt.BaseProps = append(
+			opt.BaseProps,
+			model.NewTypedPropertyValue(prop.Pred, prop.Val, prop.ValType, prop.Lang),
+		)
+	}
+	for _, syn := range smt.Synonyms {
+		if len(syn.Xrefs) > 0 {
+			opt.Synonyms = append(
+				opt.Synonyms,
+				model.NewSynonymWithRefs(syn.Pred, syn.Val, syn.Xrefs),
+			)
+
+			continue
+		}
+		opt.Synonyms = append(opt.Synonyms, model.NewSynonym(syn.Pred, syn.Val))
+	}
+	for _, xref := range smt.Xrefs {
+		opt.Xrefs = append(opt.Xrefs, model.NewXref(xref.Val))
+	}
+
+	return model.NewMeta(opt)
+}
@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(SaveSnapshot(buff, grph, "abc"), "expect no error from saving")
+	hdr, err := ReadSnapshotHeader(bytes.NewReader(buff.Bytes()))
+	assert.NoError(err, "expect no error from reading the header")
+	assert.Equal(SnapshotVersion, hdr.Version, "expect current snapshot version")
+	assert.Equal("abc", hdr.Checksum, "expect saved checksum")
+	sgrph, hdr, err := LoadSnapshot(buff)
+	assert.NoError(err, "expect no error from loading")
+	assert.Equal("abc", hdr.Checksum, "expect saved checksum")
+	assertSameGraph(assert, grph, sgrph)
+	for _, id := range []NodeID{"SO_0000340", "SO_0000110", "SO_0001217"} {
+		expected, actual := grph.GetTerm(id), sgrph.GetTerm(id)
+		assert.Equal(expected.Label(), actual.Label(), "expect same label")
+		assert.Equal(expected.IRI(), actual.IRI(), "expect same IRI")
+		assert.Equal(expected.IsDeprecated(), actual.IsDeprecated(), "expect same deprecation")
+		assert.Equal(
+			expected.Meta().Definition().Value(),
+			actual.Meta().Definition().Value(),
+			"expect same definition",
+		)
+		assert.Equal(expected.Meta().Comments(), actual.Meta().Comments(), "expect same comments")
+		assert.Equal(expected.Meta().Subsets(), actual.Meta().Subsets(), "expect same subsets")
+		assert.Len(actual.Meta().Synonyms(), len(expected.Meta().Synonyms()), "expect same synonyms")
+		assert.Len(actual.Meta().Xrefs(), len(expected.Meta().Xrefs()), "expect same xrefs")
+		assert.Len(
+			actual.Meta().BasicPropertyValues(),
+			len(expected.Meta().BasicPropertyValues()),
+			"expect same property values",
+		)
+	}
+	_, _, err = LoadSnapshot(bytes.NewBufferString("not a snapshot"))
+	assert.Error(err, "expect error from loading invalid snapshot")
+}
+
+func TestLoadOrBuildGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "so.json")
+	snapFile := filepath.Join(dir, "so.snapshot")
+	content := new(bytes.Buffer)
+	_, err = content.ReadFrom(rdr)
+	assert.NoError(err, "expect no error from reading json")
+	assert.NoError(os.WriteFile(jsonFile, content.Bytes(), 0o600))
+	grph, err := LoadOrBuildGraph(jsonFile, snapFile)
+	assert.NoError(err, "expect no error from building the graph")
+	checksum, err := FileChecksum(jsonFile)
+	assert.NoError(err, "expect no error from checksum")
+	fhr, err := os.Open(snapFile)
+	assert.NoError(err, "expect snapshot file")
+	hdr, err := ReadSnapshotHeader(fhr)
+	fhr.Close()
+	assert.NoError(err, "expect no error from reading the header")
+	assert.Equal(checksum, hdr.Checksum, "expect checksum of json file")
+	sgrph, err := LoadOrBuildGraph(jsonFile, snapFile)
+	assert.NoError(err, "expect no error from loading the snapshot")
+	assertSameGraph(assert, grph, sgrph)
+	assert.NoError(os.WriteFile(jsonFile, append(content.Bytes(), '\n'), 0o600))
+	_, err = LoadOrBuildGraph(jsonFile, snapFile)
+	assert.NoError(err, "expect no error from rebuilding the graph")
+	nchecksum, err := FileChecksum(jsonFile)
+	assert.NoError(err, "expect no error from checksum")
+	assert.NotEqual(checksum, nchecksum, "expect changed checksum")
+	fhr, err = os.Open(snapFile)
+	assert.NoError(err, "expect snapshot file")
+	defer fhr.Close()
+	hdr, err = ReadSnapshotHeader(fhr)
+	assert.NoError(err, "expect no error from reading the header")
+	assert.Equal(nchecksum, hdr.Checksum, "expect refreshed snapshot")
+}
+
+func TestLoadCorruptSnapshot(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "so.json")
+	snapFile := filepath.Join(dir, "so.snapshot")
+	content := new(bytes.Buffer)
+	_, err = content.ReadFrom(rdr)
+	assert.NoError(err, "expect no error from reading json")
+	assert.NoError(os.WriteFile(jsonFile, content.Bytes(), 0o600))
+	checksum, err := FileChecksum(jsonFile)
+	assert.NoError(err, "expect no error from checksum")
+	terms := []*snapshotTerm{{ID: "SO_0000110"}, {ID: "SO_0000704"}, {ID: "is_a"}}
+	valid := func() *snapshotGraph {
+		return &snapshotGraph{
+			ID:            "so",
+			Terms:         terms,
+			Relationships: []snapshotRelationship{{Obj: 0, Subj: 1, Pred: 2}},
+			DownOff:       []int32{0, 1, 1, 1},
+			Down:          []int32{0},
+			UpOff:         []int32{0, 0, 1, 1},
+			Up:            []int32{0},
+		}
+	}
+	sgrph, err := fromSnapshot(valid())
+	assert.NoError(err, "expect no error from a valid snapshot")
+	assert.Equal([]NodeID{"SO_0000704"}, termPipe(sgrph.Children("SO_0000110")))
+	assert.Equal([]NodeID{"SO_0000110"}, termPipe(sgrph.Parents("SO_0000704")))
+	assert.Error(sgrph.RemoveTerm("is_a", false), "expect the restored predicate index")
+	for _, corrupt := range []func(*snapshotGraph){
+		func(sgr *snapshotGraph) { sgr.Relationships[0].Obj = -1 },
+		func(sgr *snapshotGraph) { sgr.Relationships[0].Subj = -5 },
+		func(sgr *snapshotGraph) { sgr.Relationships[0].Pred = 3 },
+		func(sgr *snapshotGraph) { sgr.DownOff = nil },
+		func(sgr *snapshotGraph) { sgr.UpOff = []int32{0, 1, 0, 1} },
+		func(sgr *snapshotGraph) { sgr.Down = []int32{-1} },
+		func(sgr *snapshotGraph) { sgr.DownOff, sgr.Down = []int32{0, 0, 1, 1}, []int32{0} },
+		func(sgr *snapshotGraph) {
+			sgr.Relationships = append(sgr.Relationships, sgr.Relationships[0])
+			sgr.DownOff, sgr.Down = []int32{0, 2, 2, 2}, []int32{0, 1}
+			sgr.UpOff, sgr.Up = []int32{0, 0, 2, 2}, []int32{0, 1}
+		},
+	} {
+		sgr := valid()
+		sgr.Relationships = append([]snapshotRelationship{}, sgr.Relationships...)
+		corrupt(sgr)
+		buff := bytes.NewBuffer(make([]byte, 0))
+		enc := gob.NewEncoder(buff)
+		assert.NoError(enc.Encode(&snapshotHeader{
+			Magic:          snapshotMagic,
+			SnapshotHeader: SnapshotHeader{Version: SnapshotVersion, Checksum: checksum},
+		}))
+		assert.NoError(enc.Encode(sgr))
+		assert.NoError(os.WriteFile(snapFile, buff.Bytes(), 0o600))
+		_, _, err = LoadSnapshot(bytes.NewReader(buff.Bytes()))
+		assert.Error(err, "expect error from corrupt indexes")
+		grph, err := LoadOrBuildGraph(jsonFile, snapFile)
+		assert.NoError(err, "expect the graph to be rebuilt from json")
+		assert.True(grph.ExistsTerm("SO_0000704"), "expect terms of the json file")
+	}
+}
+
+func BenchmarkLoadGraph(b *testing.B) {
+	content := soBytes(b)
+	grph, err := BuildGraph(bytes.NewReader(content))
+	if err != nil {
+		b.Fatalf("error in building graph %s", err)
+	}
+	buff := bytes.NewBuffer(make([]byte, 0))
+	if err := SaveSnapshot(buff, grph, ""); err != nil {
+		b.Fatalf("error in saving snapshot %s", err)
+	}
+	snap := buff.Bytes()
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := BuildGraph(bytes.NewReader(content)); err != nil {
+				b.Fatalf("error in building graph %s", err)
+			}
+		}
+	})
+	b.Run("snapshot", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := LoadSnapshot(bytes.NewReader(snap)); err != nil {
+				b.Fatalf("error in loading snapshot %s", err)
+			}
+		}
+	})
+}
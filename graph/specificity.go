@@ -0,0 +1,77 @@
+package graph
+
+// Siblings returns the terms that share at least one parent with the given
+// term, excluding the term itself. When predicates are given, only
+// relationships with those predicates are followed, both to the parents and
+// from the parents to the siblings.
+func Siblings(grph OboGraph, idn NodeID, preds ...NodeID) []Term {
+	trm := make([]Term, 0)
+	seen := map[NodeID]bool{idn: true}
+	for _, parent := range ParentsByPredicate(grph, idn, preds...) {
+		for _, child := range ChildrenByPredicate(grph, parent.ID(), preds...) {
+			if seen[child.ID()] {
+				continue
+			}
+			seen[child.ID()] = true
+			trm = append(trm, child)
+		}
+	}
+
+	return trm
+}
+
+// MostSpecific reduces a set of terms to its non redundant subset by removing
+// every term that is an ancestor of another term in the set. The order of the
+// given ids is kept and duplicates are removed. When predicates are given,
+// only relationships with those predicates are followed.
+func MostSpecific(grph OboGraph, ids []NodeID, preds ...NodeID) []NodeID {
+	uniq := uniqueIDs(ids)
+	implied := make(map[NodeID]bool)
+	for _, id := range uniq {
+		for _, anc := range AncestorsByPredicate(grph, id, preds...) {
+			implied[anc.ID()] = true
+		}
+	}
+
+	return filterIDs(uniq, implied)
+}
+
+// MostGeneral reduces a set of terms to its most general subset by removing
+// every term that is a descendent of another term in the set. The order of
+// the given ids is kept and duplicates are removed. When predicates are
+// given, only relationships with those predicates are followed.
+func MostGeneral(grph OboGraph, ids []NodeID, preds ...NodeID) []NodeID {
+	uniq := uniqueIDs(ids)
+	implied := make(map[NodeID]bool)
+	for _, id := range uniq {
+		for _, desc := range DescendentsByPredicate(grph, id, preds...) {
+			implied[desc.ID()] = true
+		}
+	}
+
+	return filterIDs(uniq, implied)
+}
+
+func uniqueIDs(ids []NodeID) []NodeID {
+	uniq := make([]NodeID, 0, len(ids))
+	seen := make(map[NodeID]bool)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniq = append(uniq, id)
+		}
+	}
+
+	return uniq
+}
+
+func filterIDs(ids []NodeID, skip map[NodeID]bool) []NodeID {
+	kept := make([]NodeID, 0, len(ids))
+	for _, id := range ids {
+		if !skip[id] {
+			kept = append(kept, id)
+		}
+	}
+
+	return kept
+}
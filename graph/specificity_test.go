@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSiblings(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sibs := termPipe(Siblings(grph, NodeID("SO_0000548"), NodeID("is_a")))
+	for _, id := range []NodeID{"SO_0000455", "SO_0000451", "SO_0000693"} {
+		assert.Containsf(sibs, id, "expect %s to be a sibling", id)
+	}
+	assert.NotContains(sibs, NodeID("SO_0000548"), "expect term not to be its own sibling")
+	assert.Empty(Siblings(grph, NodeID("SO_9999999")), "expect no sibling of unknown term")
+	isaSibs := termPipe(Siblings(grph, NodeID("SO_0001217"), NodeID("is_a")))
+	allSibs := termPipe(Siblings(grph, NodeID("SO_0001217")))
+	assert.Greater(len(allSibs), len(isaSibs), "expect more siblings through all predicates")
+	assert.NotContains(isaSibs, NodeID("SO_0001217"), "expect term not to be its own sibling")
+}
+
+func TestMostSpecificAndGeneral(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	isa := NodeID("is_a")
+	ids := []NodeID{"SO_0000704", "SO_0001217", "SO_0000548", "SO_0000340", "SO_0001217"}
+	assert.Equal(
+		[]NodeID{"SO_0000548", "SO_0000340"},
+		MostSpecific(grph, ids, isa),
+		"expect only the most specific terms",
+	)
+	assert.Equal(
+		[]NodeID{"SO_0000704", "SO_0000340"},
+		MostGeneral(grph, ids, isa),
+		"expect only the most general terms",
+	)
+	assert.Empty(MostSpecific(grph, nil), "expect empty result for empty set")
+}
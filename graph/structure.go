@@ -0,0 +1,162 @@
+package graph
+
+// Roots returns the top level class terms of the graph, the ones without any
+// parent. The owl concepts that are added to every graph, the deprecated
+// terms and the terms of other types, such as properties, are ignored. When
+// predicates are given, only relationships with those predicates are
+// considered.
+func Roots(grph OboGraph, preds ...NodeID) []Term {
+	return filterTerms(grph, func(trm Term) bool {
+		return len(ParentsByPredicate(grph, trm.ID(), preds...)) == 0
+	})
+}
+
+// RootsInNamespace returns the top level class terms that belong to the
+// given namespace.
+func RootsInNamespace(grph OboGraph, nsp string, preds ...NodeID) []Term {
+	return filterTerms(grph, func(trm Term) bool {
+		return trm.Meta().Namespace() == nsp &&
+			len(ParentsByPredicate(grph, trm.ID(), preds...)) == 0
+	})
+}
+
+// Leaves returns the class terms without any children. The owl concepts that
+// are added to every graph, the deprecated terms and the terms of other
+// types are ignored. When predicates are given, only relationships with
+// those predicates are considered.
+func Leaves(grph OboGraph, preds ...NodeID) []Term {
+	return filterTerms(grph, func(trm Term) bool {
+		return len(ChildrenByPredicate(grph, trm.ID(), preds...)) == 0
+	})
+}
+
+// Depth returns the minimum and maximum number of relationships between a
+// term and the roots above it. A root has a depth of zero, and -1 is returned
+// for unknown terms or terms that cannot reach any root. The terms of a cycle
+// are collapsed into a single one, so they share the same depths and the
+// relationships within the cycle are not counted.
+func Depth(grph OboGraph, idn NodeID, preds ...NodeID) (int, int) {
+	if !grph.ExistsTerm(idn) {
+		return -1, -1
+	}
+	cnd := condense(grph, []NodeID{idn}, preds, func(nid NodeID) bool {
+		return len(ParentsByPredicate(grph, nid, preds...)) == 0
+	})
+
+	return cnd.depth(idn)
+}
+
+// LevelHistogram returns the number of class terms at every level of the
+// graph, indexed by level. The level of a term is its minimum depth from the
+// class roots, the roots being at level zero, and the terms of a cycle share
+// the same level as in Depth. Terms that cannot reach any root are not
+// counted.
+func LevelHistogram(grph OboGraph, preds ...NodeID) []int {
+	roots := make(map[NodeID]bool)
+	for _, trm := range Roots(grph, preds...) {
+		roots[trm.ID()] = true
+	}
+	terms := filterTerms(grph, func(Term) bool { return true })
+	ids := make([]NodeID, 0, len(terms))
+	for _, trm := range terms {
+		ids = append(ids, trm.ID())
+	}
+	cnd := condense(grph, ids, preds, func(nid NodeID) bool {
+		return roots[nid]
+	})
+	hist := make([]int, 0)
+	for _, nid := range ids {
+		level, _ := cnd.depth(nid)
+		if level < 0 {
+			continue
+		}
+		for len(hist) <= level {
+			hist = append(hist, 0)
+		}
+		hist[level]++
+	}
+
+	return hist
+}
+
+// condensation has the depths of the strongly connected components of the
+// terms, the groups of terms that are mutually reachable through a cycle, so
+// that the depths are computed on an acyclic graph whatever the order of
+// traversal.
+type condensation struct {
+	comp map[NodeID]int
+	dmin []int
+	dmax []int
+}
+
+// condense computes the depths of the components of the given terms and of
+// their ancestors. A component is a root when it is a single term accepted
+// by isRoot.
+func condense(
+	grph OboGraph,
+	ids []NodeID,
+	preds []NodeID,
+	isRoot func(NodeID) bool,
+) *condensation {
+	trj := newTarjan(grph, preds)
+	for _, idn := range ids {
+		if _, ok := trj.indices[idn]; !ok {
+			trj.connect(idn)
+		}
+	}
+	cnd := &condensation{
+		comp: make(map[NodeID]int),
+		dmin: make([]int, len(trj.comps)),
+		dmax: make([]int, len(trj.comps)),
+	}
+	for cdx, members := range trj.comps {
+		for _, nid := range members {
+			cnd.comp[nid] = cdx
+		}
+	}
+	// the components of the ancestors come first
+	for cdx, members := range trj.comps {
+		cnd.dmin[cdx], cnd.dmax[cdx] = -1, -1
+		if len(members) == 1 && isRoot(members[0]) {
+			cnd.dmin[cdx], cnd.dmax[cdx] = 0, 0
+
+			continue
+		}
+		for _, nid := range members {
+			for _, parent := range ParentsByPredicate(grph, nid, preds...) {
+				pdx := cnd.comp[parent.ID()]
+				if pdx == cdx || cnd.dmin[pdx] < 0 {
+					continue
+				}
+				if cnd.dmin[cdx] < 0 || cnd.dmin[pdx]+1 < cnd.dmin[cdx] {
+					cnd.dmin[cdx] = cnd.dmin[pdx] + 1
+				}
+				if cnd.dmax[pdx]+1 > cnd.dmax[cdx] {
+					cnd.dmax[cdx] = cnd.dmax[pdx] + 1
+				}
+			}
+		}
+	}
+
+	return cnd
+}
+
+func (c *condensation) depth(idn NodeID) (int, int) {
+	cdx := c.comp[idn]
+
+	return c.dmin[cdx], c.dmax[cdx]
+}
+
+func filterTerms(grph OboGraph, keep func(Term) bool) []Term {
+	trm := make([]Term, 0)
+	for _, t := range grph.Terms() {
+		if syntheticTerms[t.ID()] || t.IsDeprecated() || t.RdfType() != "CLASS" {
+			continue
+		}
+		if keep(t) {
+			trm = append(trm, t)
+		}
+	}
+
+	return trm
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphRootsAndLeaves(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	isa := NodeID("is_a")
+	roots := termPipe(Roots(grph, isa))
+	assert.ElementsMatch(
+		[]NodeID{"SO_0001060", "SO_0000110", "SO_0000400", "SO_0001260"},
+		roots,
+		"expect only the class roots of SO",
+	)
+	assert.NotContains(roots, NodeID("SO_0000704"), "expect gene not to be a root")
+	assert.NotContains(roots, isa, "expect synthetic is_a term to be ignored")
+	assert.NotContains(roots, NodeID("SO_1000100"), "expect deprecated term to be ignored")
+	nsroots := termPipe(RootsInNamespace(grph, SEQ, isa))
+	assert.Contains(nsroots, NodeID("SO_0000110"), "expect sequence_feature in sequence namespace")
+	assert.Empty(RootsInNamespace(grph, "nonexistent", isa), "expect no roots")
+	leaves := termPipe(Leaves(grph, isa))
+	assert.Contains(leaves, NodeID("SO_0000548"), "expect SO_0000548 to be a leaf")
+	assert.NotContains(leaves, NodeID("SO_0001217"), "expect SO_0001217 not to be a leaf")
+}
+
+func TestGraphDepth(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	isa := NodeID("is_a")
+	dmin, dmax := Depth(grph, NodeID("SO_0000110"), isa)
+	assert.Equal(0, dmin, "expect root to have zero min depth")
+	assert.Equal(0, dmax, "expect root to have zero max depth")
+	dmin, dmax = Depth(grph, NodeID("SO_0000336"), isa)
+	assert.Equal(3, dmin, "expect min depth of 3")
+	assert.Equal(3, dmax, "expect max depth of 3")
+	dmin, dmax = Depth(grph, NodeID("SO_0000336"))
+	assert.Equal(3, dmin, "expect min depth of 3 through all predicates")
+	assert.GreaterOrEqual(dmax, 4, "expect longer path through all predicates")
+	dmin, dmax = Depth(grph, NodeID("SO_9999999"), isa)
+	assert.Equal(-1, dmin, "expect -1 for unknown term")
+	assert.Equal(-1, dmax, "expect -1 for unknown term")
+	hist := LevelHistogram(grph, isa)
+	assert.Equal(4, hist[0], "expect the four class roots at level zero")
+	total := 0
+	for _, cnt := range hist {
+		total += cnt
+	}
+	assert.LessOrEqual(total, len(grph.Terms()), "expect every term counted at most once")
+}
+
+func TestGraphDepthWithCycle(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	for _, ids := range [][]NodeID{
+		{"R", "A", "B", "C", "D", "E"},
+		{"E", "D", "C", "B", "A", "R"},
+	} {
+		grph := newOboGraph(model.NewMeta(&model.MetaOptions{}), "test", "", "test")
+		grph.AddTerm(buildIsaTerm())
+		for _, id := range ids {
+			grph.AddTerm(NewTerm(id, "CLASS", string(id), string(id)))
+		}
+		for _, rel := range [][2]NodeID{
+			{"R", "A"}, {"B", "A"}, {"A", "B"}, {"B", "C"},
+			{"A", "D"}, {"R", "E"}, {"C", "E"},
+		} {
+			assert.NoError(grph.AddRelationshipWithID(rel[0], rel[1], "is_a"))
+		}
+		for id, exp := range map[NodeID][2]int{
+			"R": {0, 0},
+			"A": {1, 1},
+			"B": {1, 1},
+			"C": {2, 2},
+			"D": {2, 2},
+			"E": {1, 3},
+		} {
+			dmin, dmax := Depth(grph, id)
+			assert.Equalf(exp, [2]int{dmin, dmax}, "expect the depths of %s whatever the term order", id)
+		}
+		assert.Equal([]int{1, 3, 2}, LevelHistogram(grph), "expect the terms of the cycle on the same level")
+	}
+	dmin, dmax := Depth(cyclicGraph(assert), "A")
+	assert.Equal(-1, dmin, "expect no root above a cycle without roots")
+	assert.Equal(-1, dmax, "expect no root above a cycle without roots")
+}
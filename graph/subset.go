@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Subset is a named subset of the terms of a graph, also known as a slim.
+type Subset struct {
+	IRI string
+	// Name is the short name of the subset, the part of the IRI after the
+	// last # or /
+	Name string
+	// Count is the number of terms in the subset
+	Count int
+}
+
+// SlimMapping is the outcome of mapping terms to a slim.
+type SlimMapping struct {
+	// Mapped relates every mapped term to its nearest slim terms, in the
+	// order of the slim
+	Mapped map[NodeID][]NodeID
+	// Unmapped are the terms missing from the graph or without any slim
+	// ancestor, in the order they were given
+	Unmapped []NodeID
+}
+
+// Subsets returns the subsets declared in the metadata of the graph or used
+// by any of its terms, sorted by name.
+func Subsets(grph OboGraph) []*Subset {
+	sbs := make(map[string]*Subset)
+	add := func(iri string) *Subset {
+		if _, ok := sbs[iri]; !ok {
+			sbs[iri] = &Subset{IRI: iri, Name: subsetName(iri)}
+		}
+
+		return sbs[iri]
+	}
+	if grph.Meta() != nil {
+		for _, iri := range grph.Meta().Subsets() {
+			add(iri)
+		}
+	}
+	grph.EachTerm(func(trm Term) bool {
+		if !trm.HasMeta() {
+			return true
+		}
+		for _, iri := range trm.Meta().Subsets() {
+			add(iri).Count++
+		}
+
+		return true
+	})
+	lst := make([]*Subset, 0, len(sbs))
+	for _, s := range sbs {
+		lst = append(lst, s)
+	}
+	sort.Slice(lst, func(i, j int) bool {
+		if lst[i].Name != lst[j].Name {
+			return lst[i].Name < lst[j].Name
+		}
+
+		return lst[i].IRI < lst[j].IRI
+	})
+
+	return lst
+}
+
+// TermsInSubset returns the terms in the subset, given by its IRI or short
+// name, in the order of the graph.
+func TermsInSubset(grph OboGraph, name string) []Term {
+	trms := make([]Term, 0)
+	grph.EachTerm(func(trm Term) bool {
+		if hasSubset(trm, []string{name}) {
+			trms = append(trms, trm)
+		}
+
+		return true
+	})
+
+	return trms
+}
+
+// Map2Slim maps terms to their nearest slim terms, following only the
+// relationships with the given predicates, or all of them when none is
+// given. A slim term maps to itself. A slim ancestor is left out when it is
+// also an ancestor of another slim ancestor of the term.
+func Map2Slim(grph OboGraph, slim []NodeID, ids []NodeID, preds ...NodeID) *SlimMapping {
+	res := &SlimMapping{
+		Mapped:   make(map[NodeID][]NodeID),
+		Unmapped: make([]NodeID, 0),
+	}
+	inSlim := make(map[NodeID]bool)
+	for _, idn := range slim {
+		inSlim[idn] = true
+	}
+	seen := make(map[NodeID]bool)
+	for _, idn := range ids {
+		if seen[idn] {
+			continue
+		}
+		seen[idn] = true
+		slms := nearestSlims(grph, slim, inSlim, idn, preds)
+		if len(slms) == 0 {
+			res.Unmapped = append(res.Unmapped, idn)
+
+			continue
+		}
+		res.Mapped[idn] = slms
+	}
+
+	return res
+}
+
+// Map2SlimCounts sums annotation counts of terms up to their nearest slim
+// terms, as mapped by Map2Slim. A term mapped to several slim terms adds its
+// count to each of them. The counts of the unmapped terms are summed
+// separately.
+func Map2SlimCounts(
+	grph OboGraph,
+	slim []NodeID,
+	counts map[NodeID]int,
+	preds ...NodeID,
+) (map[NodeID]int, int) {
+	ids := make([]NodeID, 0, len(counts))
+	for idn := range counts {
+		ids = append(ids, idn)
+	}
+	slc := make(map[NodeID]int)
+	mpg := Map2Slim(grph, slim, ids, preds...)
+	for idn, slms := range mpg.Mapped {
+		for _, sid := range slms {
+			slc[sid] += counts[idn]
+		}
+	}
+	unmapped := 0
+	for _, idn := range mpg.Unmapped {
+		unmapped += counts[idn]
+	}
+
+	return slc, unmapped
+}
+
+func nearestSlims(
+	grph OboGraph,
+	slim []NodeID,
+	inSlim map[NodeID]bool,
+	idn NodeID,
+	preds []NodeID,
+) []NodeID {
+	if !grph.ExistsTerm(idn) {
+		return nil
+	}
+	if inSlim[idn] {
+		return []NodeID{idn}
+	}
+	found := make(map[NodeID]bool)
+	WalkAncestors(grph, idn, func(trm Term, _ int) WalkAction {
+		if !inSlim[trm.ID()] {
+			return Continue
+		}
+		found[trm.ID()] = true
+
+		return Prune
+	}, preds...)
+	for sid := range found {
+		if !found[sid] {
+			continue
+		}
+		WalkAncestors(grph, sid, func(trm Term, _ int) WalkAction {
+			delete(found, trm.ID())
+
+			return Continue
+		}, preds...)
+	}
+	slms := make([]NodeID, 0, len(found))
+	for _, sid := range slim {
+		if found[sid] {
+			slms = append(slms, sid)
+			delete(found, sid)
+		}
+	}
+
+	return slms
+}
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsets(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sbs := Subsets(grph)
+	assert.Len(sbs, 4, "expect four subsets")
+	names := make([]string, 0, len(sbs))
+	for _, s := range sbs {
+		names = append(names, s.Name)
+	}
+	assert.Equal([]string{"Alliance_of_Genome_Resources", "DBVAR", "SOFA", "biosapiens"}, names)
+	assert.Equal("http://purl.obolibrary.org/obo/so#SOFA", sbs[2].IRI)
+	assert.Equal(260, sbs[2].Count)
+
+	trms := TermsInSubset(grph, "SOFA")
+	assert.Len(trms, 260, "expect all terms of the subset")
+	assert.Len(TermsInSubset(grph, sbs[2].IRI), 260, "expect the subset by its IRI")
+	assert.Empty(TermsInSubset(grph, "GOSLIM"), "expect no term for an unknown subset")
+}
+
+func TestMap2Slim(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	slim := termPipe(TermsInSubset(grph, "SOFA"))
+	mpg := Map2Slim(grph, slim, []NodeID{"SO_0001217", "SO_0000188", "SO_9999999", "SO_0001217"}, "is_a")
+	assert.Equal([]NodeID{"SO_0000704"}, mpg.Mapped["SO_0001217"], "expect the nearest slim ancestor")
+	assert.Equal([]NodeID{"SO_0000188"}, mpg.Mapped["SO_0000188"], "expect a slim term to map to itself")
+	assert.Equal([]NodeID{"SO_9999999"}, mpg.Unmapped)
+
+	mpg = Map2Slim(grph, []NodeID{"SO_0001411", "SO_0000704"}, []NodeID{"SO_0001217"}, "is_a")
+	assert.Equal([]NodeID{"SO_0000704"}, mpg.Mapped["SO_0001217"], "expect the redundant ancestor to be left out")
+	mpg = Map2Slim(grph, []NodeID{"SO_0005855"}, []NodeID{"SO_0000704"}, "is_a")
+	assert.Equal([]NodeID{"SO_0000704"}, mpg.Unmapped, "expect the other predicates not to be followed")
+	mpg = Map2Slim(grph, []NodeID{"SO_0005855"}, []NodeID{"SO_0000704"})
+	assert.Equal([]NodeID{"SO_0005855"}, mpg.Mapped["SO_0000704"], "expect all predicates to be followed")
+
+	counts, unmapped := Map2SlimCounts(
+		grph, slim,
+		map[NodeID]int{"SO_0001217": 2, "SO_0000704": 3, "SO_9999999": 4},
+		"is_a",
+	)
+	assert.Equal(5, counts["SO_0000704"], "expect the counts summed up to the slim term")
+	assert.Equal(4, unmapped)
+}
@@ -0,0 +1,255 @@
+package graph
+
+import (
+	"sync"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// SyncGraph is an OboGraph that is safe for concurrent use by multiple
+// goroutines. Every method runs under a lock of the underlying graph, reads
+// share the lock while writes hold it exclusively. Functions that call
+// several methods of the graph, for example AncestorsByPredicate, see a
+// consistent graph in every call but not across the calls.
+type SyncGraph interface {
+	OboGraph
+	// Replace atomically swaps the underlying graph, for example with a
+	// freshly built one
+	Replace(OboGraph)
+}
+
+type syncGraph struct {
+	mu   sync.RWMutex
+	grph OboGraph
+}
+
+// NewSyncGraph wraps a graph for concurrent use. The wrapped graph should not
+// be accessed directly afterwards.
+func NewSyncGraph(grph OboGraph) SyncGraph {
+	return &syncGraph{grph: grph}
+}
+
+// Replace atomically swaps the underlying graph.
+func (s *syncGraph) Replace(grph OboGraph) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grph = grph
+}
+
+// IRI represents a stable URL for locating the source OWL formatted file.
+func (s *syncGraph) IRI() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.IRI()
+}
+
+// ID is a short and unique name of the graph.
+func (s *syncGraph) ID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.ID()
+}
+
+// Label is a short human readable description of the graph.
+func (s *syncGraph) Label() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Label()
+}
+
+// Meta returns the associated Meta container.
+func (s *syncGraph) Meta() *model.Meta {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Meta()
+}
+
+// ExistsTerm checks for existence of a term.
+func (s *syncGraph) ExistsTerm(id NodeID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.ExistsTerm(id)
+}
+
+// GetTerm fetches an existing term.
+func (s *syncGraph) GetTerm(id NodeID) Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.GetTerm(id)
+}
+
+// GetRelationship fetches relationship(edge) between parent(object) and
+// children(subject).
+func (s *syncGraph) GetRelationship(obj, subj NodeID) Relationship {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.GetRelationship(obj, subj)
+}
+
+// Relationships returns all relationships(edges) in the graph.
+func (s *syncGraph) Relationships() []Relationship {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Relationships()
+}
+
+// Terms returns all terms(node/vertex) in the graph.
+func (s *syncGraph) Terms() []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Terms()
+}
+
+// TermsByType provides a filtered list of specific terms.
+func (s *syncGraph) TermsByType(rtype string) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.TermsByType(rtype)
+}
+
+// Children returns all children terms(depth one).
+func (s *syncGraph) Children(id NodeID) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Children(id)
+}
+
+// Parents returns all parent terms(depth one).
+func (s *syncGraph) Parents(id NodeID) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Parents(id)
+}
+
+// Ancestors returns all reachable(direct or indirect) parent terms. It uses
+// BFS algorithm.
+func (s *syncGraph) Ancestors(id NodeID) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Ancestors(id)
+}
+
+// Descendents returns all reachable(direct or indirect) children terms. It uses
+// BFS algorithm.
+func (s *syncGraph) Descendents(id NodeID) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.Descendents(id)
+}
+
+// DescendentsDFS returns all reachable(direct or indirect) children terms
+// using DFS algorithm.
+func (s *syncGraph) DescendentsDFS(id NodeID) []Term {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.grph.DescendentsDFS(id)
+}
+
+// EachTerm calls the function for every term until it returns false. The
+// read lock is held during the whole iteration, so the function must not call
+// any method of the graph.
+func (s *syncGraph) EachTerm(fn func(Term) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.grph.EachTerm(fn)
+}
+
+// EachRelationship calls the function for every relationship until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncGraph) EachRelationship(fn func(Relationship) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.grph.EachRelationship(fn)
+}
+
+// EachChild calls the function for every children term(depth one) until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncGraph) EachChild(id NodeID, fn func(Term, Relationship) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.grph.EachChild(id, fn)
+}
+
+// EachParent calls the function for every parent term(depth one) until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncGraph) EachParent(id NodeID, fn func(Term, Relationship) bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.grph.EachParent(id, fn)
+}
+
+// AddRelationship creates relationship between terms, it overrides the
+// existing terms and relationship.
+func (s *syncGraph) AddRelationship(obj, subj, pred Term) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.AddRelationship(obj, subj, pred)
+}
+
+// AddRelationshipWithID creates relationship between existing terms.
+func (s *syncGraph) AddRelationshipWithID(obj, subj, pred NodeID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.AddRelationshipWithID(obj, subj, pred)
+}
+
+// AddTerm add a new Term to the graph overwriting any existing one.
+func (s *syncGraph) AddTerm(t Term) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grph.AddTerm(t)
+}
+
+// UpdateTerm replaces an existing term keeping its relationships.
+func (s *syncGraph) UpdateTerm(t Term) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.UpdateTerm(t)
+}
+
+// RemoveTerm removes a term from the graph.
+func (s *syncGraph) RemoveTerm(id NodeID, cascade bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.RemoveTerm(id, cascade)
+}
+
+// RemoveRelationship removes the relationship between parent(object) and
+// children(subject) with the given predicate.
+func (s *syncGraph) RemoveRelationship(obj, subj, pred NodeID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.RemoveRelationship(obj, subj, pred)
+}
+
+// ObsoleteTerm marks a term as deprecated, removes all of its relationships
+// and records the replacement and the terms to consider instead of it.
+func (s *syncGraph) ObsoleteTerm(id, replacedBy NodeID, consider ...NodeID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.grph.ObsoleteTerm(id, replacedBy, consider...)
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncGraphParallelReadWrite(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sgrph := NewSyncGraph(grph)
+	parent := NodeID("SO_0001217")
+	workers := 8
+	var wgr sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wgr.Add(2)
+		go func(idx int) {
+			defer wgr.Done()
+			for j := 0; j < 50; j++ {
+				id := NodeID(fmt.Sprintf("TEST_%d_%d", idx, j))
+				sgrph.AddTerm(NewTerm(id, "CLASS", string(id), string(id)))
+				if err := sgrph.AddRelationshipWithID(parent, id, "is_a"); err != nil {
+					t.Errorf("error in adding relationship %s", err)
+				}
+				if j%2 == 0 {
+					if err := sgrph.RemoveTerm(id, true); err != nil {
+						t.Errorf("error in removing term %s", err)
+					}
+				}
+			}
+		}(i)
+		go func() {
+			defer wgr.Done()
+			for j := 0; j < 50; j++ {
+				_ = sgrph.Children(parent)
+				_ = sgrph.Descendents("SO_0000704")
+				_ = sgrph.Terms()
+				_ = sgrph.Relationships()
+				_ = AncestorsByPredicate(sgrph, parent, "is_a")
+			}
+		}()
+	}
+	wgr.Wait()
+	assert.Len(sgrph.Children(parent), 4+workers*25, "expect half of the added children to be kept")
+}
+
+func TestSyncGraphReplace(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sgrph := NewSyncGraph(grph)
+	var wgr sync.WaitGroup
+	wgr.Add(2)
+	go func() {
+		defer wgr.Done()
+		for j := 0; j < 100; j++ {
+			_ = sgrph.Parents("SO_0000336")
+			_ = sgrph.ExistsTerm("SO_0000336")
+		}
+	}()
+	go func() {
+		defer wgr.Done()
+		rdr, err := getReader()
+		if err != nil {
+			t.Errorf("error in getting reader %s", err)
+
+			return
+		}
+		fresh, err := BuildGraph(rdr)
+		if err != nil {
+			t.Errorf("error in building graph %s", err)
+
+			return
+		}
+		if err := fresh.RemoveTerm("SO_0000336", true); err != nil {
+			t.Errorf("error in removing term %s", err)
+		}
+		sgrph.Replace(fresh)
+	}()
+	wgr.Wait()
+	assert.False(sgrph.ExistsTerm("SO_0000336"), "expect the replaced graph to be used")
+	assert.True(grph.ExistsTerm("SO_0000336"), "expect the original graph to be untouched")
+}
+
+func TestViewOfSyncGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	sgrph := NewSyncGraph(grph)
+	vgrph := NewView(NewView(sgrph, NotDeprecated(), nil), nil, WithPredicate("is_a"))
+	assert.IsType(&syncView{}, vgrph, "expect the views to share the lock of the graph")
+	assert.False(vgrph.ExistsTerm("SO_1000100"), "expect the filter of the inner view")
+	for _, rel := range vgrph.Relationships() {
+		assert.Equal(NodeID("is_a"), rel.Predicate(), "expect the filter of the outer view")
+	}
+	parent := NodeID("SO_0001217")
+	workers := 8
+	var wgr sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wgr.Add(2)
+		go func(idx int) {
+			defer wgr.Done()
+			for j := 0; j < 50; j++ {
+				id := NodeID(fmt.Sprintf("TEST_%d_%d", idx, j))
+				vgrph.AddTerm(NewTerm(id, "CLASS", string(id), string(id)))
+				if err := vgrph.AddRelationshipWithID(parent, id, "is_a"); err != nil {
+					t.Errorf("error in adding relationship %s", err)
+				}
+			}
+		}(i)
+		go func() {
+			defer wgr.Done()
+			for j := 0; j < 50; j++ {
+				_ = vgrph.Relationships()
+				_ = vgrph.Descendents("SO_0000704")
+				_ = vgrph.Children(parent)
+			}
+		}()
+	}
+	wgr.Wait()
+	assert.Len(vgrph.Children(parent), 4+workers*50, "expect the added is_a children")
+}
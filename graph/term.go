@@ -86,7 +86,7 @@ func (n *node) Meta() *model.Meta {
 		return n.meta
 	}
 
-	return &model.Meta{}
+	return model.NewMeta(&model.MetaOptions{})
 }
 
 // RdfType is one defined rdf type, either of CLASS,
@@ -104,3 +104,49 @@ func (n *node) Label() string {
 func (n *node) IRI() string {
 	return n.iri
 }
+
+// obsoleteTerm returns a deprecated copy of a term that records the
+// deprecation as an owl:deprecated property, its replacement and the terms to
+// consider instead of it.
+func obsoleteTerm(grph OboGraph, trm Term, replacedBy NodeID, consider []NodeID) Term {
+	opt := trm.Meta().Options()
+	opt.Deprecated = true
+	if !hasPropertyPred(opt.BaseProps, model.DeprecatedIRI) {
+		opt.BaseProps = append(opt.BaseProps, model.NewTypedPropertyValue(
+			model.DeprecatedIRI, "true", model.XSDBoolean, "",
+		))
+	}
+	if len(replacedBy) > 0 {
+		opt.BaseProps = append(opt.BaseProps, model.NewBasicPropertyValue(
+			model.ReplacedByIRI, termIRI(grph, replacedBy),
+		))
+	}
+	for _, cid := range consider {
+		opt.BaseProps = append(opt.BaseProps, model.NewBasicPropertyValue(
+			model.ConsiderIRI, termIRI(grph, cid),
+		))
+	}
+
+	return NewTermWithMeta(
+		trm.ID(), model.NewMeta(opt), trm.RdfType(), trm.Label(), trm.IRI(),
+	)
+}
+
+func hasPropertyPred(props []*model.BasicPropertyValue, pred string) bool {
+	for _, prop := range props {
+		if prop.Pred() == pred {
+			return true
+		}
+	}
+
+	return false
+}
+
+// termIRI returns the IRI of an existing term or the id itself.
+func termIRI(grph OboGraph, idn NodeID) string {
+	if grph.ExistsTerm(idn) && len(grph.GetTerm(idn).IRI()) > 0 {
+		return grph.GetTerm(idn).IRI()
+	}
+
+	return string(idn)
+}
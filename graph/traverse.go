@@ -0,0 +1,85 @@
+package graph
+
+// ParentsByPredicate returns the parent terms(depth one) that are connected
+// through any of the given predicates. All parents are returned when no
+// predicate is given.
+func ParentsByPredicate(grph OboGraph, idn NodeID, preds ...NodeID) []Term {
+	trm := make([]Term, 0)
+	grph.EachParent(idn, func(parent Term, rel Relationship) bool {
+		if hasPredicate(rel.Predicate(), preds) {
+			trm = append(trm, parent)
+		}
+
+		return true
+	})
+
+	return trm
+}
+
+// ChildrenByPredicate returns the children terms(depth one) that are connected
+// through any of the given predicates. All children are returned when no
+// predicate is given.
+func ChildrenByPredicate(grph OboGraph, idn NodeID, preds ...NodeID) []Term {
+	trm := make([]Term, 0)
+	grph.EachChild(idn, func(child Term, rel Relationship) bool {
+		if hasPredicate(rel.Predicate(), preds) {
+			trm = append(trm, child)
+		}
+
+		return true
+	})
+
+	return trm
+}
+
+// AncestorsByPredicate returns all reachable(direct or indirect) parent terms
+// following only the given predicates. It uses BFS algorithm.
+func AncestorsByPredicate(grph OboGraph, idn NodeID, preds ...NodeID) []Term {
+	return bfs(grph, idn, func(nid NodeID) []Term {
+		return ParentsByPredicate(grph, nid, preds...)
+	})
+}
+
+// DescendentsByPredicate returns all reachable(direct or indirect) children
+// terms following only the given predicates. It uses BFS algorithm.
+func DescendentsByPredicate(grph OboGraph, idn NodeID, preds ...NodeID) []Term {
+	return bfs(grph, idn, func(nid NodeID) []Term {
+		return ChildrenByPredicate(grph, nid, preds...)
+	})
+}
+
+func bfs(grph OboGraph, idn NodeID, next func(NodeID) []Term) []Term {
+	trm := make([]Term, 0)
+	if !grph.ExistsTerm(idn) {
+		return trm
+	}
+	qid := []NodeID{idn}
+	visited := map[NodeID]bool{idn: true}
+	for len(qid) > 0 {
+		nid := qid[0]
+		qid = qid[1:]
+		for _, nxt := range next(nid) {
+			if visited[nxt.ID()] {
+				continue
+			}
+			visited[nxt.ID()] = true
+			qid = append(qid, nxt.ID())
+			trm = append(trm, nxt)
+		}
+	}
+
+	return trm
+}
+
+func hasPredicate(pred NodeID, preds []NodeID) bool {
+	if len(preds) == 0 {
+		return true
+	}
+	for _, p := range preds {
+		if p == pred {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,673 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/model"
+)
+
+// TermFilter decides whether a term is part of a view.
+type TermFilter func(Term) bool
+
+// EdgeFilter decides whether a relationship is part of a view.
+type EdgeFilter func(Relationship) bool
+
+type view struct {
+	grph     OboGraph
+	termFltr TermFilter
+	edgeFltr EdgeFilter
+}
+
+// NewView returns a graph that shows only the terms and relationships of the
+// underlying graph that pass the filters, a nil filter lets everything pass.
+// A relationship is part of the view only when its parent(object), children
+// (subject) and predicate terms are part of it. The filters are evaluated on
+// every access, so the view always reflects the current state of the
+// underlying graph. The changes made through the view are applied to the
+// underlying graph, but only the terms and relationships of the view can be
+// removed or obsoleted. A view of a SyncGraph takes the lock of the graph
+// once for every call, so it is safe for concurrent use as long as the
+// filters do not call the graph, and a view of such a view combines the
+// filters of both.
+func NewView(grph OboGraph, termFltr TermFilter, edgeFltr EdgeFilter) OboGraph {
+	if termFltr == nil {
+		termFltr = func(Term) bool { return true }
+	}
+	if edgeFltr == nil {
+		edgeFltr = func(Relationship) bool { return true }
+	}
+	switch src := grph.(type) {
+	case *syncGraph:
+		return &syncView{src: src, termFltr: termFltr, edgeFltr: edgeFltr}
+	case *syncView:
+		return &syncView{
+			src:      src.src,
+			termFltr: AllTerms(src.termFltr, termFltr),
+			edgeFltr: func(rel Relationship) bool {
+				return src.edgeFltr(rel) && edgeFltr(rel)
+			},
+		}
+	}
+
+	return &view{grph: grph, termFltr: termFltr, edgeFltr: edgeFltr}
+}
+
+// AllTerms combines term filters, a term has to pass all of them.
+func AllTerms(fltrs ...TermFilter) TermFilter {
+	return func(trm Term) bool {
+		for _, fltr := range fltrs {
+			if !fltr(trm) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// AnyTerm combines term filters, a term has to pass any of them.
+func AnyTerm(fltrs ...TermFilter) TermFilter {
+	return func(trm Term) bool {
+		for _, fltr := range fltrs {
+			if fltr(trm) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// NotDeprecated filters out the deprecated terms.
+func NotDeprecated() TermFilter {
+	return func(trm Term) bool {
+		return !trm.IsDeprecated()
+	}
+}
+
+// InNamespace keeps the terms of any of the given namespaces. The property
+// terms are kept too, so that the relationships of the view keep their
+// predicates.
+func InNamespace(nsps ...string) TermFilter {
+	return func(trm Term) bool {
+		if trm.RdfType() == "PROPERTY" {
+			return true
+		}
+		nsp := trm.Meta().Namespace()
+		for _, n := range nsps {
+			if n == nsp {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// InSubset keeps the terms that belong to any of the given subsets, either
+// given as the subset IRI or its short name, for example SOFA. The property
+// terms are kept too, so that the relationships of the view keep their
+// predicates.
+func InSubset(subsets ...string) TermFilter {
+	return func(trm Term) bool {
+		return trm.RdfType() == "PROPERTY" || hasSubset(trm, subsets)
+	}
+}
+
+// hasSubset tells whether a term belongs to any of the subsets, given by IRI
+// or short name.
+func hasSubset(trm Term, subsets []string) bool {
+	if !trm.HasMeta() {
+		return false
+	}
+	for _, sbs := range trm.Meta().Subsets() {
+		for _, s := range subsets {
+			if s == sbs || s == subsetName(sbs) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// OfRdfType keeps the terms of any of the given RDF types. The PROPERTY type
+// has to be included for a view with relationships.
+func OfRdfType(rtypes ...string) TermFilter {
+	return func(trm Term) bool {
+		for _, r := range rtypes {
+			if r == trm.RdfType() {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// WithPredicate keeps the relationships with any of the given predicates.
+func WithPredicate(preds ...NodeID) EdgeFilter {
+	return func(rel Relationship) bool {
+		return hasPredicate(rel.Predicate(), preds)
+	}
+}
+
+// subsetName returns the short name of a subset IRI, the part after the last
+// # or /.
+func subsetName(iri string) string {
+	return iri[strings.LastIndexAny(iri, "#/")+1:]
+}
+
+// Label is a short human readable description of the graph.
+func (v *view) Label() string {
+	return v.grph.Label()
+}
+
+// ID is a short and unique name of the graph.
+func (v *view) ID() string {
+	return v.grph.ID()
+}
+
+// IRI represents a stable URL for locating the source OWL formatted file.
+func (v *view) IRI() string {
+	return v.grph.IRI()
+}
+
+// Meta returns the associated Meta container.
+func (v *view) Meta() *model.Meta {
+	return v.grph.Meta()
+}
+
+// ExistsTerm checks for existence of a term.
+func (v *view) ExistsTerm(id NodeID) bool {
+	return v.GetTerm(id) != nil
+}
+
+// GetTerm fetches an existing term.
+func (v *view) GetTerm(id NodeID) Term {
+	trm := v.grph.GetTerm(id)
+	if trm == nil || !v.termFltr(trm) {
+		return nil
+	}
+
+	return trm
+}
+
+// GetRelationship fetches relationship(edge) between parent(object) and
+// children(subject).
+func (v *view) GetRelationship(obj, subj NodeID) (rel Relationship) {
+	if r := v.grph.GetRelationship(obj, subj); r != nil && v.hasRelationship(r) {
+		return r
+	}
+
+	return rel
+}
+
+// Relationships returns all relationships(edges) in the graph.
+func (v *view) Relationships() []Relationship {
+	var rel []Relationship
+	v.EachRelationship(func(r Relationship) bool {
+		rel = append(rel, r)
+
+		return true
+	})
+
+	return rel
+}
+
+// Terms returns all terms(node/vertex) in the graph.
+func (v *view) Terms() []Term {
+	trm := make([]Term, 0)
+	v.EachTerm(func(t Term) bool {
+		trm = append(trm, t)
+
+		return true
+	})
+
+	return trm
+}
+
+// TermsByType provides a filtered list of specific terms.
+func (v *view) TermsByType(rtype string) []Term {
+	trm := make([]Term, 0)
+	v.EachTerm(func(t Term) bool {
+		if t.RdfType() == rtype {
+			trm = append(trm, t)
+		}
+
+		return true
+	})
+
+	return trm
+}
+
+// Children returns all children terms(depth one).
+func (v *view) Children(id NodeID) []Term {
+	trm := make([]Term, 0)
+	v.EachChild(id, func(child Term, _ Relationship) bool {
+		trm = append(trm, child)
+
+		return true
+	})
+
+	return trm
+}
+
+// Parents returns all parent terms(depth one).
+func (v *view) Parents(id NodeID) []Term {
+	trm := make([]Term, 0)
+	v.EachParent(id, func(parent Term, _ Relationship) bool {
+		trm = append(trm, parent)
+
+		return true
+	})
+
+	return trm
+}
+
+// Ancestors returns all reachable(direct or indirect) parent terms. It uses
+// BFS algorithm.
+func (v *view) Ancestors(idn NodeID) []Term {
+	var atrm []Term
+	if !v.ExistsTerm(idn) {
+		return atrm
+	}
+	visited := map[NodeID]bool{idn: true}
+	qid := []NodeID{idn}
+	for len(qid) > 0 {
+		nid := qid[len(qid)-1]
+		qid = qid[:len(qid)-1]
+		for _, parent := range v.Parents(nid) {
+			if !visited[parent.ID()] {
+				visited[parent.ID()] = true
+				qid = append(qid, parent.ID())
+				atrm = append(atrm, parent)
+			}
+		}
+	}
+
+	return atrm
+}
+
+// Descendents returns all reachable(direct or indirect) children terms. It uses
+// BFS algorithm.
+func (v *view) Descendents(idn NodeID) []Term {
+	return bfs(v, idn, v.Children)
+}
+
+// DescendentsDFS returns all reachable(direct or indirect) children terms
+// using DFS algorithm.
+func (v *view) DescendentsDFS(idn NodeID) []Term {
+	drm := make([]Term, 0)
+	if !v.ExistsTerm(idn) {
+		return drm
+	}
+	visited := make(map[NodeID]bool)
+	stn := []NodeID{idn}
+	for len(stn) > 0 {
+		nid := stn[len(stn)-1]
+		stn = stn[:len(stn)-1]
+		visited[nid] = true
+		for _, child := range v.Children(nid) {
+			if !visited[child.ID()] {
+				drm = append(drm, child)
+				stn = append(stn, child.ID())
+			}
+		}
+	}
+
+	return drm
+}
+
+// EachTerm calls the function for every term, in the same order as Terms,
+// until it returns false.
+func (v *view) EachTerm(fn func(Term) bool) {
+	v.grph.EachTerm(func(trm Term) bool {
+		if !v.termFltr(trm) {
+			return true
+		}
+
+		return fn(trm)
+	})
+}
+
+// EachRelationship calls the function for every relationship, in the same
+// order as Relationships, until it returns false.
+func (v *view) EachRelationship(fn func(Relationship) bool) {
+	v.grph.EachRelationship(func(rel Relationship) bool {
+		if !v.hasRelationship(rel) {
+			return true
+		}
+
+		return fn(rel)
+	})
+}
+
+// EachChild calls the function for every children term(depth one) along with
+// the relationship to it until it returns false.
+func (v *view) EachChild(id NodeID, fn func(Term, Relationship) bool) {
+	if !v.ExistsTerm(id) {
+		return
+	}
+	v.grph.EachChild(id, v.related(fn))
+}
+
+// EachParent calls the function for every parent term(depth one) along with
+// the relationship to it until it returns false.
+func (v *view) EachParent(id NodeID, fn func(Term, Relationship) bool) {
+	if !v.ExistsTerm(id) {
+		return
+	}
+	v.grph.EachParent(id, v.related(fn))
+}
+
+// AddRelationship creates relationship between terms, it overrides the
+// existing terms and relationship.
+func (v *view) AddRelationship(obj, subj, pred Term) error {
+	return v.grph.AddRelationship(obj, subj, pred)
+}
+
+// AddRelationshipWithID creates relationship between existing terms.
+func (v *view) AddRelationshipWithID(obj, subj, pred NodeID) error {
+	return v.grph.AddRelationshipWithID(obj, subj, pred)
+}
+
+// AddTerm add a new Term to the graph overwriting any existing one.
+func (v *view) AddTerm(t Term) {
+	v.grph.AddTerm(t)
+}
+
+// UpdateTerm replaces an existing term keeping its relationships.
+func (v *view) UpdateTerm(t Term) error {
+	if !v.ExistsTerm(t.ID()) {
+		return fmt.Errorf("node id %s does not exist", t.ID())
+	}
+
+	return v.grph.UpdateTerm(t)
+}
+
+// RemoveTerm removes a term from the graph.
+func (v *view) RemoveTerm(idn NodeID, cascade bool) error {
+	if !v.ExistsTerm(idn) {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+
+	return v.grph.RemoveTerm(idn, cascade)
+}
+
+// RemoveRelationship removes the relationship between parent(object) and
+// children(subject) with the given predicate.
+func (v *view) RemoveRelationship(obj, subj, pred NodeID) error {
+	rel := v.GetRelationship(obj, subj)
+	if rel == nil || rel.Predicate() != pred {
+		return fmt.Errorf(
+			"relationship %s between %s and %s does not exist",
+			pred, obj, subj,
+		)
+	}
+
+	return v.grph.RemoveRelationship(obj, subj, pred)
+}
+
+// ObsoleteTerm marks a term as deprecated, removes all of its relationships
+// and records the replacement and the terms to consider instead of it.
+func (v *view) ObsoleteTerm(idn, replacedBy NodeID, consider ...NodeID) error {
+	if !v.ExistsTerm(idn) {
+		return fmt.Errorf("node id %s does not exist", idn)
+	}
+
+	return v.grph.ObsoleteTerm(idn, replacedBy, consider...)
+}
+
+func (v *view) hasRelationship(rel Relationship) bool {
+	return v.edgeFltr(rel) &&
+		v.ExistsTerm(rel.Object()) &&
+		v.ExistsTerm(rel.Subject()) &&
+		v.ExistsTerm(rel.Predicate())
+}
+
+// related wraps the function for neighbouring terms to skip the terms and
+// relationships that are not part of the view.
+func (v *view) related(fn func(Term, Relationship) bool) func(Term, Relationship) bool {
+	return func(trm Term, rel Relationship) bool {
+		if !v.termFltr(trm) || !v.edgeFltr(rel) || !v.ExistsTerm(rel.Predicate()) {
+			return true
+		}
+
+		return fn(trm, rel)
+	}
+}
+
+// syncView is a view of a SyncGraph. Every method takes the lock of the
+// graph once and runs a view of the underlying graph, so that the view never
+// calls back into the locked graph.
+type syncView struct {
+	src      *syncGraph
+	termFltr TermFilter
+	edgeFltr EdgeFilter
+}
+
+// view returns a view of the current underlying graph, it must be called
+// with the lock held.
+func (s *syncView) view() *view {
+	return &view{grph: s.src.grph, termFltr: s.termFltr, edgeFltr: s.edgeFltr}
+}
+
+// IRI represents a stable URL for locating the source OWL formatted file.
+func (s *syncView) IRI() string {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().IRI()
+}
+
+// ID is a short and unique name of the graph.
+func (s *syncView) ID() string {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().ID()
+}
+
+// Label is a short human readable description of the graph.
+func (s *syncView) Label() string {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Label()
+}
+
+// Meta returns the associated Meta container.
+func (s *syncView) Meta() *model.Meta {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Meta()
+}
+
+// ExistsTerm checks for existence of a term.
+func (s *syncView) ExistsTerm(id NodeID) bool {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().ExistsTerm(id)
+}
+
+// GetTerm fetches an existing term.
+func (s *syncView) GetTerm(id NodeID) Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().GetTerm(id)
+}
+
+// GetRelationship fetches relationship(edge) between parent(object) and
+// children(subject).
+func (s *syncView) GetRelationship(obj, subj NodeID) Relationship {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().GetRelationship(obj, subj)
+}
+
+// Relationships returns all relationships(edges) in the graph.
+func (s *syncView) Relationships() []Relationship {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Relationships()
+}
+
+// Terms returns all terms(node/vertex) in the graph.
+func (s *syncView) Terms() []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Terms()
+}
+
+// TermsByType provides a filtered list of specific terms.
+func (s *syncView) TermsByType(rtype string) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().TermsByType(rtype)
+}
+
+// Children returns all children terms(depth one).
+func (s *syncView) Children(id NodeID) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Children(id)
+}
+
+// Parents returns all parent terms(depth one).
+func (s *syncView) Parents(id NodeID) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Parents(id)
+}
+
+// Ancestors returns all reachable(direct or indirect) parent terms. It uses
+// BFS algorithm.
+func (s *syncView) Ancestors(id NodeID) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Ancestors(id)
+}
+
+// Descendents returns all reachable(direct or indirect) children terms. It uses
+// BFS algorithm.
+func (s *syncView) Descendents(id NodeID) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().Descendents(id)
+}
+
+// DescendentsDFS returns all reachable(direct or indirect) children terms
+// using DFS algorithm.
+func (s *syncView) DescendentsDFS(id NodeID) []Term {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+
+	return s.view().DescendentsDFS(id)
+}
+
+// EachTerm calls the function for every term until it returns false. The
+// read lock is held during the whole iteration, so the function must not call
+// any method of the graph.
+func (s *syncView) EachTerm(fn func(Term) bool) {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+	s.view().EachTerm(fn)
+}
+
+// EachRelationship calls the function for every relationship until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncView) EachRelationship(fn func(Relationship) bool) {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+	s.view().EachRelationship(fn)
+}
+
+// EachChild calls the function for every children term(depth one) until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncView) EachChild(id NodeID, fn func(Term, Relationship) bool) {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+	s.view().EachChild(id, fn)
+}
+
+// EachParent calls the function for every parent term(depth one) until it
+// returns false. The read lock is held during the whole iteration, so the
+// function must not call any method of the graph.
+func (s *syncView) EachParent(id NodeID, fn func(Term, Relationship) bool) {
+	s.src.mu.RLock()
+	defer s.src.mu.RUnlock()
+	s.view().EachParent(id, fn)
+}
+
+// AddRelationship creates relationship between terms, it overrides the
+// existing terms and relationship.
+func (s *syncView) AddRelationship(obj, subj, pred Term) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().AddRelationship(obj, subj, pred)
+}
+
+// AddRelationshipWithID creates relationship between existing terms.
+func (s *syncView) AddRelationshipWithID(obj, subj, pred NodeID) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().AddRelationshipWithID(obj, subj, pred)
+}
+
+// AddTerm add a new Term to the graph overwriting any existing one.
+func (s *syncView) AddTerm(t Term) {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+	s.view().AddTerm(t)
+}
+
+// UpdateTerm replaces an existing term keeping its relationships.
+func (s *syncView) UpdateTerm(t Term) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().UpdateTerm(t)
+}
+
+// RemoveTerm removes a term from the graph.
+func (s *syncView) RemoveTerm(id NodeID, cascade bool) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().RemoveTerm(id, cascade)
+}
+
+// RemoveRelationship removes the relationship between parent(object) and
+// children(subject) with the given predicate.
+func (s *syncView) RemoveRelationship(obj, subj, pred NodeID) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().RemoveRelationship(obj, subj, pred)
+}
+
+// ObsoleteTerm marks a term as deprecated, removes all of its relationships
+// and records the replacement and the terms to consider instead of it.
+func (s *syncView) ObsoleteTerm(id, replacedBy NodeID, consider ...NodeID) error {
+	s.src.mu.Lock()
+	defer s.src.mu.Unlock()
+
+	return s.view().ObsoleteTerm(id, replacedBy, consider...)
+}
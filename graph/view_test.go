@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	assertSameGraph(assert, grph, NewView(grph, nil, nil))
+
+	vgrph := NewView(grph, NotDeprecated(), nil)
+	for _, trm := range vgrph.Terms() {
+		assert.False(trm.IsDeprecated(), "expect no deprecated term")
+	}
+	assert.Less(len(vgrph.Terms()), len(grph.Terms()), "expect fewer terms")
+	for _, rel := range vgrph.Relationships() {
+		assert.True(vgrph.ExistsTerm(rel.Object()), "expect parent in the view")
+		assert.True(vgrph.ExistsTerm(rel.Subject()), "expect children in the view")
+		assert.True(vgrph.ExistsTerm(rel.Predicate()), "expect predicate in the view")
+	}
+
+	nsgrph := NewView(grph, InNamespace(SEQ), nil)
+	for _, trm := range nsgrph.TermsByType("CLASS") {
+		assert.Equal(SEQ, trm.Meta().Namespace(), "expect sequence namespace")
+	}
+	for _, trm := range nsgrph.Descendents("SO_0000110") {
+		assert.Equal(SEQ, trm.Meta().Namespace(), "expect sequence namespace")
+	}
+	ogrph := NewView(grph, InNamespace("other"), nil)
+	assert.Empty(ogrph.TermsByType("CLASS"), "expect no term of other namespace")
+	assert.False(ogrph.ExistsTerm("SO_0000110"), "expect no sequence term")
+	assert.Empty(ogrph.Children("SO_0000110"), "expect no children of a hidden term")
+	assert.Len(
+		nsgrph.TermsByType("PROPERTY"),
+		len(grph.TermsByType("PROPERTY")),
+		"expect all property terms",
+	)
+
+	sbgrph := NewView(grph, InSubset("SOFA"), nil)
+	for _, trm := range sbgrph.TermsByType("CLASS") {
+		assert.Contains(
+			trm.Meta().Subsets(),
+			"http://purl.obolibrary.org/obo/so#SOFA",
+			"expect SOFA subset",
+		)
+	}
+	assert.NotEmpty(sbgrph.TermsByType("CLASS"), "expect SOFA terms")
+
+	isa := NodeID("is_a")
+	pgrph := NewView(grph, nil, WithPredicate(isa))
+	assert.Equal(
+		termPipe(ParentsByPredicate(grph, "SO_0000704", isa)),
+		termPipe(pgrph.Parents("SO_0000704")),
+		"expect only is_a parents",
+	)
+	assert.Equal(
+		termPipe(AncestorsByPredicate(grph, "SO_0000704", isa)),
+		termPipe(pgrph.Ancestors("SO_0000704")),
+		"expect only is_a ancestors",
+	)
+	assert.Nil(pgrph.GetRelationship("SO_0000010", "SO_0001217"), "expect no has_quality relationship")
+	for _, rel := range pgrph.Relationships() {
+		assert.Equal(isa, rel.Predicate(), "expect is_a relationship")
+	}
+
+	cgrph := NewView(grph, OfRdfType("CLASS"), nil)
+	assert.Empty(cgrph.Relationships(), "expect no relationship without property terms")
+}
+
+func TestViewMutation(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	vgrph := NewView(grph, NotDeprecated(), nil)
+	assert.True(vgrph.ExistsTerm("SO_0001217"), "expect the term in the view")
+	assert.NoError(vgrph.ObsoleteTerm("SO_0001217", "SO_0000704"))
+	assert.False(vgrph.ExistsTerm("SO_0001217"), "expect obsoleted term to be hidden")
+	assert.True(grph.ExistsTerm("SO_0001217"), "expect the term in the graph")
+	assert.Error(vgrph.RemoveTerm("SO_0001217", true), "expect error for hidden term")
+	assert.NoError(grph.RemoveTerm("SO_0001217", true))
+	assert.False(grph.ExistsTerm("SO_0001217"), "expect removed term")
+}
@@ -0,0 +1,78 @@
+package graph
+
+// WalkAction tells a walk how to continue after visiting a term.
+type WalkAction int
+
+const (
+	// Continue goes on with the walk through the visited term.
+	Continue WalkAction = iota
+	// Prune goes on with the walk but skips the terms that are only
+	// reachable through the visited term.
+	Prune
+	// Stop ends the walk.
+	Stop
+)
+
+// Visitor is called with every term reached by a walk and its distance, in
+// number of relationships, from the starting term. It must not modify the
+// graph, nor call any method of a SyncGraph which is locked during the walk.
+type Visitor func(Term, int) WalkAction
+
+// WalkDescendants visits all reachable(direct or indirect) children terms in
+// BFS order without collecting them. Every term is visited once, the visitor
+// decides whether to continue, prune or stop the walk. When predicates are
+// given, only relationships with those predicates are followed.
+func WalkDescendants(grph OboGraph, idn NodeID, visit Visitor, preds ...NodeID) {
+	walk(grph.EachChild, grph, idn, visit, preds)
+}
+
+// WalkAncestors visits all reachable(direct or indirect) parent terms in BFS
+// order without collecting them. Every term is visited once, the visitor
+// decides whether to continue, prune or stop the walk. When predicates are
+// given, only relationships with those predicates are followed.
+func WalkAncestors(grph OboGraph, idn NodeID, visit Visitor, preds ...NodeID) {
+	walk(grph.EachParent, grph, idn, visit, preds)
+}
+
+func walk(
+	each func(NodeID, func(Term, Relationship) bool),
+	grph OboGraph,
+	idn NodeID,
+	visit Visitor,
+	preds []NodeID,
+) {
+	if !grph.ExistsTerm(idn) {
+		return
+	}
+	type step struct {
+		id    NodeID
+		depth int
+	}
+	qid := []step{{id: idn}}
+	visited := map[NodeID]bool{idn: true}
+	for len(qid) > 0 {
+		cur := qid[0]
+		qid = qid[1:]
+		stop := false
+		each(cur.id, func(nxt Term, rel Relationship) bool {
+			if visited[nxt.ID()] || !hasPredicate(rel.Predicate(), preds) {
+				return true
+			}
+			visited[nxt.ID()] = true
+			switch visit(nxt, cur.depth+1) {
+			case Stop:
+				stop = true
+
+				return false
+			case Continue:
+				qid = append(qid, step{id: nxt.ID(), depth: cur.depth + 1})
+			case Prune:
+			}
+
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphEach(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	ids := make([]NodeID, 0)
+	grph.EachTerm(func(trm Term) bool {
+		ids = append(ids, trm.ID())
+
+		return true
+	})
+	assert.Equal(termPipe(grph.Terms()), ids, "expect all terms in order")
+	count := 0
+	grph.EachTerm(func(trm Term) bool {
+		count++
+
+		return count < 10
+	})
+	assert.Equal(10, count, "expect iteration to stop")
+	nrel := 0
+	grph.EachRelationship(func(rel Relationship) bool {
+		nrel++
+
+		return true
+	})
+	assert.Len(grph.Relationships(), nrel, "expect all relationships")
+	children := make([]NodeID, 0)
+	grph.EachChild("SO_0001217", func(trm Term, rel Relationship) bool {
+		assert.Equal(NodeID("SO_0001217"), rel.Object(), "expect relationship to the child")
+		children = append(children, trm.ID())
+
+		return true
+	})
+	assert.Equal(termPipe(grph.Children("SO_0001217")), children, "expect same children")
+	parents := make([]NodeID, 0)
+	grph.EachParent("SO_0001217", func(trm Term, rel Relationship) bool {
+		parents = append(parents, trm.ID())
+
+		return false
+	})
+	assert.Len(parents, 1, "expect iteration to stop after first parent")
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	term := NodeID("SO_0001217")
+	visited := make([]NodeID, 0)
+	WalkDescendants(grph, term, func(trm Term, depth int) WalkAction {
+		visited = append(visited, trm.ID())
+
+		return Continue
+	})
+	assert.Equal(termPipe(grph.Descendents(term)), visited, "expect same terms as Descendents")
+	depths := make(map[NodeID]int)
+	WalkDescendants(grph, term, func(trm Term, depth int) WalkAction {
+		depths[trm.ID()] = depth
+
+		return Prune
+	})
+	assert.Len(depths, 4, "expect only the children with pruning")
+	for id, depth := range depths {
+		assert.Equalf(1, depth, "expect %s at depth one", id)
+	}
+	count := 0
+	WalkDescendants(grph, term, func(trm Term, depth int) WalkAction {
+		count++
+		if count == 3 {
+			return Stop
+		}
+
+		return Continue
+	})
+	assert.Equal(3, count, "expect walk to stop")
+	ancestors := make([]NodeID, 0)
+	WalkAncestors(grph, "SO_0000336", func(trm Term, depth int) WalkAction {
+		ancestors = append(ancestors, trm.ID())
+
+		return Continue
+	}, "is_a")
+	assert.Equal(
+		termPipe(AncestorsByPredicate(grph, "SO_0000336", "is_a")),
+		ancestors,
+		"expect same terms as AncestorsByPredicate",
+	)
+}
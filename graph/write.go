@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/dictyBase/go-obograph/schema"
+)
+
+// WriteJSON writes the graph in obograph JSON format, which can be read back
+// by BuildGraph. The owl concepts that BuildGraph adds as terms are not
+// written.
+func WriteJSON(w io.Writer, grph OboGraph) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(toOboJSON(grph)); err != nil {
+		return fmt.Errorf("error in encoding obograph json %s", err)
+	}
+
+	return nil
+}
+
+func toOboJSON(grph OboGraph) *schema.OboJSON {
+	ogf := &schema.OboJSONGraph{
+		ID:    grph.IRI(),
+		Lbl:   grph.Label(),
+		Meta:  toJSONMeta(grph.Meta()),
+		Nodes: make([]*schema.JSONNode, 0),
+		Edges: make([]*schema.JSONEdge, 0),
+	}
+	grph.EachTerm(func(trm Term) bool {
+		if syntheticTerms[trm.ID()] {
+			return true
+		}
+		jnn := &schema.JSONNode{
+			ID:       jsonTermID(grph, trm.ID()),
+			Lbl:      trm.Label(),
+			JSONType: trm.RdfType(),
+		}
+		if trm.HasMeta() {
+			jnn.Meta = toJSONMeta(trm.Meta())
+		}
+		ogf.Nodes = append(ogf.Nodes, jnn)
+
+		return true
+	})
+	grph.EachRelationship(func(rel Relationship) bool {
+		ogf.Edges = append(ogf.Edges, &schema.JSONEdge{
+			Obj:  jsonTermID(grph, rel.Object()),
+			Sub:  jsonTermID(grph, rel.Subject()),
+			Pred: jsonTermID(grph, rel.Predicate()),
+		})
+
+		return true
+	})
+
+	return &schema.OboJSON{Graphs: []*schema.OboJSONGraph{ogf}}
+}
+
+// jsonTermID returns the IRI of a term, the owl concepts are written with
+// their short names the way obograph JSON refers to them.
+func jsonTermID(grph OboGraph, idn NodeID) string {
+	if syntheticTerms[idn] {
+		return string(idn)
+	}
+
+	return termIRI(grph, idn)
+}
+
+func toJSONMeta(mta *model.Meta) *schema.JSONMeta {
+	jsm := &schema.JSONMeta{}
+	if mta == nil {
+		return jsm
+	}
+	opt := mta.Options()
+	jsm.Version = opt.Version
+	jsm.Deprecated = opt.Deprecated
+	if len(opt.Comments) > 0 {
+		jsm.Comments = opt.Comments
+	}
+	if len(opt.Subsets) > 0 {
+		jsm.Subsets = opt.Subsets
+	}
+	if opt.Definition != nil {
+		jsm.Definition = &schema.JSONDefintion{
+			Val:   opt.Definition.Value(),
+			Xrefs: opt.Definition.Xrefs(),
+		}
+	}
+	for _, prop := range opt.BaseProps {
+		jsm.BasicPropertyValues = append(
+			jsm.BasicPropertyValues,
+			&schema.JSONProperty{
+				Pred:    prop.Pred(),
+				Val:     prop.Value(),
+				ValType: prop.ValType(),
+				Lang:    prop.Lang(),
+			},
+		)
+	}
+	for _, syn := range opt.Synonyms {
+		jsm.Synonyms = append(jsm.Synonyms, &schema.JSONSynonym{
+			Pred:  syn.Pred(),
+			Val:   syn.Value(),
+			Xrefs: syn.Xrefs(),
+		})
+	}
+	for _, xref := range opt.Xrefs {
+		jsm.Xrefs = append(jsm.Xrefs, struct {
+			Val string `json:"val"`
+		}{Val: xref.Value()})
+	}
+
+	return jsm
+}
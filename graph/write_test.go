@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(WriteJSON(buff, grph), "expect no error from writing json")
+	rgrph, err := BuildGraph(buff)
+	assert.NoError(err, "expect no error from building the written graph")
+	assertSameGraph(assert, grph, rgrph)
+	assert.Equal(grph.Meta().Version(), rgrph.Meta().Version(), "expect same version")
+	assert.Equal(grph.Meta().Namespace(), rgrph.Meta().Namespace(), "expect same namespace")
+	for _, trm := range grph.Terms() {
+		rtrm := rgrph.GetTerm(trm.ID())
+		assert.Equal(trm.Label(), rtrm.Label(), "expect same label")
+		assert.Equal(trm.IRI(), rtrm.IRI(), "expect same IRI")
+		assert.Equal(trm.RdfType(), rtrm.RdfType(), "expect same type")
+		assert.Equal(trm.IsDeprecated(), rtrm.IsDeprecated(), "expect same deprecation")
+		if !trm.HasMeta() {
+			continue
+		}
+		assert.Equal(trm.Meta().Comments(), rtrm.Meta().Comments(), "expect same comments")
+		assert.Equal(trm.Meta().Subsets(), rtrm.Meta().Subsets(), "expect same subsets")
+		assert.Equal(trm.Meta().XrefsValues(), rtrm.Meta().XrefsValues(), "expect same xrefs")
+		assert.Len(rtrm.Meta().Synonyms(), len(trm.Meta().Synonyms()), "expect same synonyms")
+	}
+}
+
+func TestWriteJSONTypedProperties(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	doc := `{"graphs": [{
+		"id": "http://example.org/typed.owl",
+		"meta": {"version": "typed"},
+		"nodes": [{
+			"id": "http://purl.obolibrary.org/obo/SO_0000704",
+			"type": "CLASS",
+			"lbl": "gene",
+			"meta": {"basicPropertyValues": [
+				{"pred": "http://www.w3.org/2000/01/rdf-schema#label", "val": "gène", "lang": "fr"},
+				{"pred": "http://www.geneontology.org/formats/oboInOwl#creation_date",
+				 "val": "2015-06-18T11:24:55Z", "valType": "xsd:dateTime"}
+			]}
+		}],
+		"edges": []
+	}]}`
+	grph, err := BuildGraph(bytes.NewBufferString(doc))
+	assert.NoError(err, "expect no error from building the graph")
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(WriteJSON(buff, grph), "expect no error from writing json")
+	rgrph, err := BuildGraph(buff)
+	assert.NoError(err, "expect no error from building the written graph")
+	props := rgrph.GetTerm("SO_0000704").Meta().BasicPropertyValues()
+	assert.Len(props, 2, "expect two property values")
+	assert.Equal("fr", props[0].Lang(), "expect language tag")
+	assert.Empty(props[0].ValType(), "expect no datatype")
+	assert.Equal("xsd:dateTime", props[1].ValType(), "expect datatype")
+	_, err = props[1].Time()
+	assert.NoError(err, "expect no error from typed time")
+}
+
+func TestWriteJSONWithoutIRI(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	doc := `{"graphs": [{
+		"id": "http://example.org/test.owl",
+		"lbl": "test ontology",
+		"meta": {"version": "test"},
+		"nodes": [{
+			"id": "http://purl.obolibrary.org/obo/SO_0000704",
+			"type": "CLASS",
+			"lbl": "gene"
+		}],
+		"edges": []
+	}]}`
+	grph, err := BuildGraph(bytes.NewBufferString(doc))
+	assert.NoError(err, "expect no error from building the graph")
+	assert.Equal("test ontology", grph.Label(), "expect the label of the graph")
+	assert.NoError(grph.AddRelationship(
+		grph.GetTerm("SO_0000704"),
+		NewTerm("TEST_1", "CLASS", "test", ""),
+		grph.GetTerm("is_a"),
+	))
+	buff := bytes.NewBuffer(make([]byte, 0))
+	assert.NoError(WriteJSON(buff, grph), "expect no error from writing json")
+	rgrph, err := BuildGraph(buff)
+	assert.NoError(err, "expect no error from building the written graph")
+	assert.Equal("test ontology", rgrph.Label(), "expect the label of the graph")
+	assert.True(rgrph.ExistsTerm("TEST_1"), "expect the term without IRI")
+	assert.Equal("TEST_1", rgrph.GetTerm("TEST_1").IRI(), "expect the id as IRI")
+	assert.Equal([]NodeID{"TEST_1"}, termPipe(rgrph.Children("SO_0000704")), "expect its relationship")
+}
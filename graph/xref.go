@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+)
+
+// URLPrefix groups the xrefs that are URLs rather than CURIEs.
+const URLPrefix = "URL"
+
+// XrefMapping is an id of another vocabulary along with the terms having it
+// as xref.
+type XrefMapping struct {
+	Xref string
+	// Terms are the ids of the current terms having the xref, more than
+	// one means the mapping is ambiguous
+	Terms []NodeID
+}
+
+// XrefTranslation is the outcome of translating ids of another vocabulary
+// through the xrefs of the terms.
+type XrefTranslation struct {
+	// Mapped are in the order of the translated ids
+	Mapped []*XrefMapping
+	// Unmapped are the ids without any current term
+	Unmapped []string
+}
+
+// XrefIndex looks up terms by their xrefs. The database prefix of the xrefs
+// is matched ignoring case, so that loinc:LA6692-3 and LOINC:LA6692-3 are the
+// same. The index reflects the graph at the time it was built.
+type XrefIndex struct {
+	grph   OboGraph
+	terms  map[string][]NodeID
+	xrefs  map[string][]string
+	prefix map[string]string
+}
+
+// NewXrefIndex indexes the xrefs of the terms of the graph.
+func NewXrefIndex(grph OboGraph) *XrefIndex {
+	idx := &XrefIndex{
+		grph:   grph,
+		terms:  make(map[string][]NodeID),
+		xrefs:  make(map[string][]string),
+		prefix: make(map[string]string),
+	}
+	grph.EachTerm(func(trm Term) bool {
+		if !trm.HasMeta() {
+			return true
+		}
+		for _, val := range trm.Meta().XrefsValues() {
+			key := normalizeXref(val)
+			if len(key) == 0 {
+				continue
+			}
+			if _, ok := idx.terms[key]; !ok {
+				pfx := XrefPrefix(val)
+				if _, ok := idx.prefix[strings.ToUpper(pfx)]; !ok {
+					idx.prefix[strings.ToUpper(pfx)] = pfx
+				}
+				idx.xrefs[strings.ToUpper(pfx)] = append(
+					idx.xrefs[strings.ToUpper(pfx)], strings.TrimSpace(val),
+				)
+			}
+			if !hasID(idx.terms[key], trm.ID()) {
+				idx.terms[key] = append(idx.terms[key], trm.ID())
+			}
+		}
+
+		return true
+	})
+	for _, xrefs := range idx.xrefs {
+		sort.Strings(xrefs)
+	}
+
+	return idx
+}
+
+// XrefPrefix returns the database part of an xref, for example MESH for
+// MESH:D001234, or URLPrefix for a URL.
+func XrefPrefix(xref string) string {
+	xref = strings.TrimSpace(xref)
+	lower := strings.ToLower(xref)
+	if strings.HasPrefix(lower, "http:") || strings.HasPrefix(lower, "https:") {
+		return URLPrefix
+	}
+	pos := strings.Index(xref, ":")
+	if pos < 0 {
+		return ""
+	}
+
+	return xref[:pos]
+}
+
+// GroupXrefs groups xrefs by their database prefix.
+func GroupXrefs(xrefs []string) map[string][]string {
+	grp := make(map[string][]string)
+	for _, val := range xrefs {
+		pfx := XrefPrefix(val)
+		grp[pfx] = append(grp[pfx], val)
+	}
+
+	return grp
+}
+
+// Terms returns the terms having the xref, in the order of the graph.
+func (x *XrefIndex) Terms(xref string) []Term {
+	trms := make([]Term, 0)
+	for _, idn := range x.terms[normalizeXref(xref)] {
+		trms = append(trms, x.grph.GetTerm(idn))
+	}
+
+	return trms
+}
+
+// Prefixes returns the database prefixes of the xrefs, sorted.
+func (x *XrefIndex) Prefixes() []string {
+	pfx := make([]string, 0, len(x.prefix))
+	for _, val := range x.prefix {
+		pfx = append(pfx, val)
+	}
+	sort.Strings(pfx)
+
+	return pfx
+}
+
+// Xrefs returns the distinct xrefs having the database prefix, sorted.
+func (x *XrefIndex) Xrefs(prefix string) []string {
+	return append([]string{}, x.xrefs[strings.ToUpper(prefix)]...)
+}
+
+// Translate maps ids of another vocabulary to the current terms having them
+// as xrefs. The ids are either CURIEs, or local ids when a prefix is given,
+// so that Translate("MESH", ids) maps D001234 through MESH:D001234.
+func (x *XrefIndex) Translate(prefix string, ids []string) *XrefTranslation {
+	trl := &XrefTranslation{
+		Mapped:   make([]*XrefMapping, 0),
+		Unmapped: make([]string, 0),
+	}
+	for _, val := range ids {
+		xref := val
+		if len(prefix) > 0 && !strings.Contains(val, ":") {
+			xref = prefix + ":" + val
+		}
+		mpg := &XrefMapping{Xref: xref, Terms: make([]NodeID, 0)}
+		for _, trm := range x.Terms(xref) {
+			if !trm.IsDeprecated() {
+				mpg.Terms = append(mpg.Terms, trm.ID())
+			}
+		}
+		if len(mpg.Terms) == 0 {
+			trl.Unmapped = append(trl.Unmapped, val)
+
+			continue
+		}
+		trl.Mapped = append(trl.Mapped, mpg)
+	}
+
+	return trl
+}
+
+// normalizeXref upper cases the database prefix of a CURIE, a URL is kept
+// as it is.
+func normalizeXref(xref string) string {
+	xref = strings.TrimSpace(xref)
+	pfx := XrefPrefix(xref)
+	if len(pfx) == 0 || pfx == URLPrefix {
+		return xref
+	}
+
+	return strings.ToUpper(pfx) + xref[len(pfx):]
+}
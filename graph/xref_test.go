@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXrefIndex(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	rdr, err := getReader()
+	assert.NoError(err, "expect no error from the reader")
+	grph, err := BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+	idx := NewXrefIndex(grph)
+
+	trms := idx.Terms("loinc:LA6692-3")
+	assert.Len(trms, 1, "expect one term for the xref")
+	assert.Equal(NodeID("SO_0000159"), trms[0].ID())
+	assert.Len(idx.Terms("LOINC:LA6692-3"), 1, "expect the prefix to be matched ignoring case")
+	assert.Empty(idx.Terms("loinc:la6692-3"), "expect the local id to be case sensitive")
+	assert.Len(idx.Terms("http://en.wikipedia.org/wiki/Silent_mutation"), 2, "expect two terms for the url")
+
+	assert.Equal("MESH", XrefPrefix("MESH:D001234"))
+	assert.Equal(URLPrefix, XrefPrefix("http:www.ensembl.org/info"))
+	assert.Equal("", XrefPrefix("D001234"))
+	pfx := idx.Prefixes()
+	assert.Contains(pfx, "RNAMOD")
+	assert.Contains(pfx, URLPrefix)
+	assert.NotContains(pfx, "http", "expect urls grouped under their own prefix")
+	xrefs := idx.Xrefs("mod")
+	assert.Len(xrefs, 21, "expect all xrefs of the database")
+	assert.Equal("MOD:00901", xrefs[0], "expect sorted xrefs")
+	grp := GroupXrefs([]string{"RNAMOD:001", "http://example.org", "RNAMOD:002"})
+	assert.Equal([]string{"RNAMOD:001", "RNAMOD:002"}, grp["RNAMOD"])
+	assert.Equal([]string{"http://example.org"}, grp[URLPrefix])
+
+	trl := idx.Translate("RNAMOD", []string{"001", "060", "999", "MOD:00901"})
+	assert.Len(trl.Mapped, 3, "expect three mapped ids")
+	assert.Equal("RNAMOD:001", trl.Mapped[0].Xref)
+	assert.Equal([]NodeID{"SO_0001295"}, trl.Mapped[0].Terms)
+	assert.ElementsMatch([]NodeID{"SO_0001352", "SO_0001354"}, trl.Mapped[1].Terms, "expect an ambiguous mapping")
+	assert.Equal([]NodeID{"SO_0001387"}, trl.Mapped[2].Terms, "expect a curie to keep its own prefix")
+	assert.Equal([]string{"999"}, trl.Unmapped)
+
+	assert.NoError(grph.ObsoleteTerm("SO_0001295", ""))
+	trl = NewXrefIndex(grph).Translate("", []string{"RNAMOD:001"})
+	assert.Empty(trl.Mapped, "expect no mapping to an obsolete term")
+	assert.Equal([]string{"RNAMOD:001"}, trl.Unmapped)
+}
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Annotation is a well known annotation property of terms, that can be
+// recorded with any of the predicates registered for it.
+type Annotation string
+
+// Annotations with typed accessors on Meta.
+const (
+	CreationDate       Annotation = "creation_date"
+	CreatedBy          Annotation = "created_by"
+	ReplacedBy         Annotation = "replaced_by"
+	Consider           Annotation = "consider"
+	AltID              Annotation = "alt_id"
+	ObsolescenceReason Annotation = "obsolescence_reason"
+	EditorNote         Annotation = "editor_note"
+)
+
+// dateLayouts are the layouts of the creation dates, RFC 3339 and its
+// variants, a plain date and the layout of the OBO format headers.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02:01:2006 15:04",
+}
+
+// Registry maps the annotations to the IRIs of their predicates. It is safe
+// for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	preds map[Annotation][]string
+	anns  map[string]Annotation
+}
+
+// DefaultRegistry is the registry used by the accessors of Meta.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns a registry with the well known predicates of the
+// annotations.
+func NewRegistry() *Registry {
+	reg := &Registry{
+		preds: make(map[Annotation][]string),
+		anns:  make(map[string]Annotation),
+	}
+	reg.Register(CreationDate, CreationDateIRI, "http://purl.org/dc/terms/created", "http://purl.org/dc/elements/1.1/date")
+	reg.Register(CreatedBy, CreatedByIRI, "http://purl.org/dc/terms/creator", "http://purl.org/dc/elements/1.1/creator")
+	reg.Register(ReplacedBy, ReplacedByIRI)
+	reg.Register(Consider, ConsiderIRI)
+	reg.Register(AltID, AltIDIRI)
+	reg.Register(ObsolescenceReason, ObsolescenceReasonIRI)
+	reg.Register(EditorNote, EditorNoteIRI)
+
+	return reg
+}
+
+// Register adds predicates to an annotation, a predicate belongs to a single
+// annotation so that registering it again moves it.
+func (r *Registry) Register(ann Annotation, iris ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, iri := range iris {
+		if old, ok := r.anns[iri]; ok {
+			r.preds[old] = removeString(r.preds[old], iri)
+		}
+		r.anns[iri] = ann
+		r.preds[ann] = append(r.preds[ann], iri)
+	}
+}
+
+// Predicates returns the IRIs of the predicates of an annotation.
+func (r *Registry) Predicates(ann Annotation) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string{}, r.preds[ann]...)
+}
+
+// Annotation returns the annotation of a predicate.
+func (r *Registry) Annotation(iri string) (Annotation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ann, ok := r.anns[iri]
+
+	return ann, ok
+}
+
+// RegisterPredicate adds predicates to an annotation of the default
+// registry.
+func RegisterPredicate(ann Annotation, iris ...string) {
+	DefaultRegistry.Register(ann, iris...)
+}
+
+// ParseDate parses a creation date in any of the layouts used by the
+// ontologies.
+func ParseDate(val string) (time.Time, error) {
+	for _, lyt := range dateLayouts {
+		if tms, err := time.Parse(lyt, val); err == nil {
+			return tms, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown date format %s", val)
+}
+
+// Annotations returns the values of an annotation, using the predicates of
+// the default registry.
+func (m *Meta) Annotations(ann Annotation) []string {
+	return m.AnnotationsFrom(DefaultRegistry, ann)
+}
+
+// AnnotationsFrom returns the values of an annotation, using the predicates
+// of the given registry.
+func (m *Meta) AnnotationsFrom(reg *Registry, ann Annotation) []string {
+	vals := make([]string, 0)
+	for _, p := range m.BasicPropertyValues() {
+		if pan, ok := reg.Annotation(p.Pred()); ok && pan == ann {
+			vals = append(vals, p.Value())
+		}
+	}
+
+	return vals
+}
+
+// Annotation returns the first value of an annotation, using the predicates
+// of the default registry.
+func (m *Meta) Annotation(ann Annotation) (string, bool) {
+	return m.AnnotationFrom(DefaultRegistry, ann)
+}
+
+// AnnotationFrom returns the first value of an annotation, using the
+// predicates of the given registry.
+func (m *Meta) AnnotationFrom(reg *Registry, ann Annotation) (string, bool) {
+	vals := m.AnnotationsFrom(reg, ann)
+	if len(vals) == 0 {
+		return "", false
+	}
+
+	return vals[0], true
+}
+
+// CreationDate returns when the term was created, false when the date is
+// either absent or in an unknown format.
+func (m *Meta) CreationDate() (time.Time, bool) {
+	val, ok := m.Annotation(CreationDate)
+	if !ok {
+		return time.Time{}, false
+	}
+	tms, err := ParseDate(val)
+
+	return tms, err == nil
+}
+
+// CreatedBy returns who created the term.
+func (m *Meta) CreatedBy() string {
+	val, _ := m.Annotation(CreatedBy)
+
+	return val
+}
+
+// ReplacedBy returns the ids or IRIs of the terms replacing an obsolete
+// term.
+func (m *Meta) ReplacedBy() []string {
+	return m.Annotations(ReplacedBy)
+}
+
+// Consider returns the ids or IRIs of the terms to consider instead of an
+// obsolete term.
+func (m *Meta) Consider() []string {
+	return m.Annotations(Consider)
+}
+
+// AltIDs returns the alternative ids of the term.
+func (m *Meta) AltIDs() []string {
+	return m.Annotations(AltID)
+}
+
+// ObsolescenceReason returns why the term was obsoleted, usually the IRI of
+// a reason such as IAO_0000227 for terms merged.
+func (m *Meta) ObsolescenceReason() string {
+	val, _ := m.Annotation(ObsolescenceReason)
+
+	return val
+}
+
+// EditorNotes returns the notes for the editors of the ontology.
+func (m *Meta) EditorNotes() []string {
+	return m.Annotations(EditorNote)
+}
+
+func removeString(vals []string, val string) []string {
+	rest := make([]string, 0, len(vals))
+	for _, v := range vals {
+		if v != val {
+			rest = append(rest, v)
+		}
+	}
+
+	return rest
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotations(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	mta := NewMeta(&MetaOptions{
+		BaseProps: []*BasicPropertyValue{
+			NewBasicPropertyValue(CreationDateIRI, "2015-06-18T11:24:55Z"),
+			NewBasicPropertyValue(CreatedByIRI, "evan"),
+			NewBasicPropertyValue(ReplacedByIRI, "http://purl.obolibrary.org/obo/SO_0001088"),
+			NewBasicPropertyValue(ConsiderIRI, "SO:0000677"),
+			NewBasicPropertyValue(ConsiderIRI, "SO:0000676"),
+			NewBasicPropertyValue(AltIDIRI, "SO:0001783"),
+			NewBasicPropertyValue(ObsolescenceReasonIRI, "http://purl.obolibrary.org/obo/IAO_0000227"),
+			NewBasicPropertyValue(EditorNoteIRI, "merged during cleanup"),
+		},
+	})
+	tms, ok := mta.CreationDate()
+	assert.True(ok, "expect a creation date")
+	assert.Equal(time.Date(2015, 6, 18, 11, 24, 55, 0, time.UTC), tms)
+	assert.Equal("evan", mta.CreatedBy())
+	assert.Equal([]string{"http://purl.obolibrary.org/obo/SO_0001088"}, mta.ReplacedBy())
+	assert.Equal([]string{"SO:0000677", "SO:0000676"}, mta.Consider())
+	assert.Equal([]string{"SO:0001783"}, mta.AltIDs())
+	assert.Equal("http://purl.obolibrary.org/obo/IAO_0000227", mta.ObsolescenceReason())
+	assert.Equal([]string{"merged during cleanup"}, mta.EditorNotes())
+
+	empty := NewMeta(&MetaOptions{})
+	_, ok = empty.CreationDate()
+	assert.False(ok, "expect no creation date")
+	assert.Empty(empty.CreatedBy())
+	assert.Empty(empty.ReplacedBy())
+	bad := NewMeta(&MetaOptions{
+		BaseProps: []*BasicPropertyValue{NewBasicPropertyValue(CreationDateIRI, "yesterday")},
+	})
+	_, ok = bad.CreationDate()
+	assert.False(ok, "expect no creation date in an unknown format")
+}
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	for val, exp := range map[string]time.Time{
+		"2009-10-23T12:52:36Z": time.Date(2009, 10, 23, 12, 52, 36, 0, time.UTC),
+		"2009-10-23T12:52:36":  time.Date(2009, 10, 23, 12, 52, 36, 0, time.UTC),
+		"2009-10-23":           time.Date(2009, 10, 23, 0, 0, 0, 0, time.UTC),
+		"22:11:2021 06:31":     time.Date(2021, 11, 22, 6, 31, 0, 0, time.UTC),
+	} {
+		tms, err := ParseDate(val)
+		assert.NoErrorf(err, "expect no error from parsing %s", val)
+		assert.Truef(exp.Equal(tms), "expect the date of %s", val)
+	}
+	_, err := ParseDate("23/10/2009")
+	assert.Error(err, "expect error for an unknown format")
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	reg := NewRegistry()
+	ann, ok := reg.Annotation(CreatedByIRI)
+	assert.True(ok)
+	assert.Equal(CreatedBy, ann)
+	_, ok = reg.Annotation("http://example.org/unknown")
+	assert.False(ok, "expect no annotation for an unknown predicate")
+	reg.Register(EditorNote, "http://example.org/note", "http://purl.org/dc/terms/creator")
+	assert.Equal([]string{EditorNoteIRI, "http://example.org/note", "http://purl.org/dc/terms/creator"}, reg.Predicates(EditorNote))
+	assert.NotContains(reg.Predicates(CreatedBy), "http://purl.org/dc/terms/creator", "expect the predicate to be moved")
+	assert.NotContains(DefaultRegistry.Predicates(EditorNote), "http://example.org/note", "expect the default registry untouched")
+	mta := NewMeta(&MetaOptions{
+		BaseProps: []*BasicPropertyValue{
+			NewBasicPropertyValue("http://example.org/note", "check the definition"),
+			NewBasicPropertyValue("http://purl.org/dc/terms/creator", "evan"),
+		},
+	})
+	assert.Equal([]string{"check the definition", "evan"}, mta.AnnotationsFrom(reg, EditorNote))
+	_, ok = mta.AnnotationFrom(reg, CreatedBy)
+	assert.False(ok, "expect the moved predicate to be left out")
+	assert.Empty(mta.EditorNotes(), "expect the default registry to be used")
+	assert.Equal("evan", mta.CreatedBy())
+}
+
+// TestRegisterPredicate is not parallel, as it swaps the default registry.
+func TestRegisterPredicate(t *testing.T) {
+	assert := require.New(t)
+	dreg := DefaultRegistry
+	DefaultRegistry = NewRegistry()
+	t.Cleanup(func() { DefaultRegistry = dreg })
+	RegisterPredicate(EditorNote, "http://example.org/curator_note")
+	mta := NewMeta(&MetaOptions{
+		BaseProps: []*BasicPropertyValue{NewBasicPropertyValue("http://example.org/curator_note", "check the definition")},
+	})
+	assert.Equal([]string{"check the definition"}, mta.EditorNotes(), "expect the registered predicate to be used")
+}
+
+func TestNilMetaOptions(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	var mta *Meta
+	assert.NotNil(mta.Options(), "expect empty options from nil meta")
+	assert.Empty(mta.Options().BaseProps)
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const xsdNamespace = "http://www.w3.org/2001/XMLSchema#"
+
+// XSD datatypes of the typed property values, as CURIEs.
+const (
+	XSDString   = "xsd:string"
+	XSDBoolean  = "xsd:boolean"
+	XSDInteger  = "xsd:integer"
+	XSDInt      = "xsd:int"
+	XSDLong     = "xsd:long"
+	XSDDateTime = "xsd:dateTime"
+	XSDDate     = "xsd:date"
+	XSDAnyURI   = "xsd:anyURI"
+)
+
+// NormalizeDatatype converts a datatype given as an XSD IRI to its CURIE,
+// any other datatype is left as it is.
+func NormalizeDatatype(valType string) string {
+	if strings.HasPrefix(valType, xsdNamespace) {
+		return "xsd:" + strings.TrimPrefix(valType, xsdNamespace)
+	}
+
+	return valType
+}
+
+// Bool returns the value as a boolean, the datatype, if any, has to be
+// xsd:boolean. Only the literals of xsd:boolean, true, false, 1 and 0, are
+// accepted.
+func (p *PropertyValue) Bool() (bool, error) {
+	if err := p.checkType(XSDBoolean); err != nil {
+		return false, err
+	}
+	switch val := strings.TrimSpace(p.val); val {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("error in parsing boolean of %s, invalid literal %q", p.prd, val)
+	}
+}
+
+// Int returns the value as an integer, the datatype, if any, has to be one
+// of the XSD integers.
+func (p *PropertyValue) Int() (int64, error) {
+	if err := p.checkType(XSDInteger, XSDInt, XSDLong); err != nil {
+		return 0, err
+	}
+	ival, err := strconv.ParseInt(strings.TrimSpace(p.val), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error in parsing integer of %s %s", p.prd, err)
+	}
+
+	return ival, nil
+}
+
+// Time returns the value as a time, in any of the layouts of ParseDate. The
+// datatype, if any, has to be xsd:dateTime or xsd:date.
+func (p *PropertyValue) Time() (time.Time, error) {
+	if err := p.checkType(XSDDateTime, XSDDate); err != nil {
+		return time.Time{}, err
+	}
+
+	return ParseDate(strings.TrimSpace(p.val))
+}
+
+// IRI returns the value as an absolute IRI, the datatype, if any, has to be
+// xsd:anyURI.
+func (p *PropertyValue) IRI() (string, error) {
+	if err := p.checkType(XSDAnyURI); err != nil {
+		return "", err
+	}
+	val := strings.TrimSpace(p.val)
+	iri, err := url.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("error in parsing IRI of %s %s", p.prd, err)
+	}
+	if !iri.IsAbs() {
+		return "", fmt.Errorf("value %s of %s is not an absolute IRI", val, p.prd)
+	}
+
+	return val, nil
+}
+
+func (p *PropertyValue) checkType(valTypes ...string) error {
+	if len(p.valType) == 0 {
+		return nil
+	}
+	vtp := NormalizeDatatype(p.valType)
+	for _, typ := range valTypes {
+		if vtp == typ {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("datatype %s of %s is not %s", p.valType, p.prd, strings.Join(valTypes, " or "))
+}
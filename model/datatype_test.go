@@ -0,0 +1,70 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedPropertyValue(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	lbl := NewTypedPropertyValue("http://www.w3.org/2000/01/rdf-schema#label", "séquence", XSDString, "fr")
+	assert.Equal(XSDString, lbl.ValType())
+	assert.Equal("fr", lbl.Lang())
+	plain := NewBasicPropertyValue(CreatedByIRI, "evan")
+	assert.Empty(plain.ValType())
+	assert.Empty(plain.Lang())
+
+	bval, err := NewTypedPropertyValue(DeprecatedIRI, "true", XSDBoolean, "").Bool()
+	assert.NoError(err, "expect no error from boolean")
+	assert.True(bval)
+	bval, err = NewTypedPropertyValue(DeprecatedIRI, "false", xsdNamespace+"boolean", "").Bool()
+	assert.NoError(err, "expect no error from boolean with IRI datatype")
+	assert.False(bval)
+	bval, err = NewBasicPropertyValue(DeprecatedIRI, "true").Bool()
+	assert.NoError(err, "expect no error from untyped boolean")
+	assert.True(bval)
+	_, err = NewTypedPropertyValue(DeprecatedIRI, "true", XSDString, "").Bool()
+	assert.Error(err, "expect error from mismatched datatype")
+	_, err = NewTypedPropertyValue(DeprecatedIRI, "yes", XSDBoolean, "").Bool()
+	assert.Error(err, "expect error from invalid boolean")
+	for val, exp := range map[string]bool{"1": true, "0": false, " true ": true} {
+		bval, err = NewTypedPropertyValue(DeprecatedIRI, val, XSDBoolean, "").Bool()
+		assert.NoErrorf(err, "expect no error from boolean %q", val)
+		assert.Equalf(exp, bval, "expect the value of boolean %q", val)
+	}
+	for _, val := range []string{"T", "F", "t", "f", "TRUE", "True", "FALSE", "False", ""} {
+		_, err = NewTypedPropertyValue(DeprecatedIRI, val, XSDBoolean, "").Bool()
+		assert.Errorf(err, "expect error from boolean %q outside xsd:boolean", val)
+	}
+
+	ival, err := NewTypedPropertyValue("count", "42", XSDInteger, "").Int()
+	assert.NoError(err, "expect no error from integer")
+	assert.Equal(int64(42), ival)
+	_, err = NewTypedPropertyValue("count", "4.2", XSDInteger, "").Int()
+	assert.Error(err, "expect error from invalid integer")
+	_, err = NewTypedPropertyValue("count", "42", XSDBoolean, "").Int()
+	assert.Error(err, "expect error from mismatched datatype")
+
+	tms, err := NewTypedPropertyValue(CreationDateIRI, "2015-06-18T11:24:55Z", XSDDateTime, "").Time()
+	assert.NoError(err, "expect no error from time")
+	assert.Equal(time.Date(2015, 6, 18, 11, 24, 55, 0, time.UTC), tms)
+	_, err = NewTypedPropertyValue(CreationDateIRI, "yesterday", XSDDateTime, "").Time()
+	assert.Error(err, "expect error from invalid time")
+
+	iri, err := NewTypedPropertyValue(ReplacedByIRI, "http://purl.obolibrary.org/obo/SO_0001088", XSDAnyURI, "").IRI()
+	assert.NoError(err, "expect no error from IRI")
+	assert.Equal("http://purl.obolibrary.org/obo/SO_0001088", iri)
+	_, err = NewBasicPropertyValue(ReplacedByIRI, "SO_0001088").IRI()
+	assert.Error(err, "expect error from relative IRI")
+}
+
+func TestNormalizeDatatype(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	assert.Equal(XSDDateTime, NormalizeDatatype("http://www.w3.org/2001/XMLSchema#dateTime"))
+	assert.Equal(XSDBoolean, NormalizeDatatype(XSDBoolean))
+	assert.Equal("http://example.org/type", NormalizeDatatype("http://example.org/type"))
+}
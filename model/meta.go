@@ -28,6 +28,22 @@ func NewMeta(opt *MetaOptions) *Meta {
 	return &Meta{opt}
 }
 
+// Options returns a copy of the options the Meta is built from, that can be
+// modified to build a new Meta.
+func (m *Meta) Options() *MetaOptions {
+	if m == nil || m.opt == nil {
+		return &MetaOptions{}
+	}
+	opt := *m.opt
+	opt.BaseProps = append([]*BasicPropertyValue(nil), m.opt.BaseProps...)
+	opt.Synonyms = append([]*Synonym(nil), m.opt.Synonyms...)
+	opt.Xrefs = append([]*Xref(nil), m.opt.Xrefs...)
+	opt.Comments = append([]string(nil), m.opt.Comments...)
+	opt.Subsets = append([]string(nil), m.opt.Subsets...)
+
+	return &opt
+}
+
 // BasicPropertyValues are the collection of meta properties.
 func (m *Meta) BasicPropertyValues() []*BasicPropertyValue {
 	var b []*BasicPropertyValue
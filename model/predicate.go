@@ -0,0 +1,33 @@
+package model
+
+// IRIs of the predicates of well known basic property values.
+const (
+	// DeprecatedIRI marks an obsolete term
+	DeprecatedIRI = "http://www.w3.org/2002/07/owl#deprecated"
+	// ReplacedByIRI points an obsolete term to its replacement
+	ReplacedByIRI = "http://purl.obolibrary.org/obo/IAO_0100001"
+	// ConsiderIRI points an obsolete term to a possible replacement
+	ConsiderIRI = "http://www.geneontology.org/formats/oboInOwl#consider"
+	// AltIDIRI records an alternative id of a term, usually a merged term
+	AltIDIRI = "http://www.geneontology.org/formats/oboInOwl#hasAlternativeId"
+	// CreationDateIRI records when a term was created
+	CreationDateIRI = "http://www.geneontology.org/formats/oboInOwl#creation_date"
+	// CreatedByIRI records who created a term
+	CreatedByIRI = "http://www.geneontology.org/formats/oboInOwl#created_by"
+	// ObsolescenceReasonIRI records why a term was obsoleted
+	ObsolescenceReasonIRI = "http://purl.obolibrary.org/obo/IAO_0000231"
+	// EditorNoteIRI records a note meant for the editors of the ontology
+	EditorNoteIRI = "http://purl.obolibrary.org/obo/IAO_0000116"
+	// DbXrefIRI records a cross reference of a term to another database
+	DbXrefIRI = "http://www.geneontology.org/formats/oboInOwl#hasDbXref"
+	// ExactMatchIRI maps a term to an equivalent concept of another scheme
+	ExactMatchIRI = "http://www.w3.org/2004/02/skos/core#exactMatch"
+	// CloseMatchIRI maps a term to a similar concept of another scheme
+	CloseMatchIRI = "http://www.w3.org/2004/02/skos/core#closeMatch"
+	// BroadMatchIRI maps a term to a broader concept of another scheme
+	BroadMatchIRI = "http://www.w3.org/2004/02/skos/core#broadMatch"
+	// NarrowMatchIRI maps a term to a narrower concept of another scheme
+	NarrowMatchIRI = "http://www.w3.org/2004/02/skos/core#narrowMatch"
+	// RelatedMatchIRI maps a term to a related concept of another scheme
+	RelatedMatchIRI = "http://www.w3.org/2004/02/skos/core#relatedMatch"
+)
@@ -3,9 +3,11 @@ package model
 // PropertyValue for modeling ontology metadata other than
 // term and their relationships.
 type PropertyValue struct {
-	val  string
-	refs []string
-	prd  string
+	val     string
+	refs    []string
+	prd     string
+	valType string
+	lang    string
 }
 
 // Value is the value of property.
@@ -23,6 +25,17 @@ func (p *PropertyValue) Pred() string {
 	return p.prd
 }
 
+// ValType is the datatype of the value, for example xsd:dateTime, either as
+// CURIE or IRI, empty for a plain value.
+func (p *PropertyValue) ValType() string {
+	return p.valType
+}
+
+// Lang is the language tag of the value, empty when not tagged.
+func (p *PropertyValue) Lang() string {
+	return p.lang
+}
+
 // BasicPropertyValue is a generic PropertyValue.
 type BasicPropertyValue struct {
 	*PropertyValue
@@ -38,6 +51,19 @@ func NewBasicPropertyValue(prd, val string) *BasicPropertyValue {
 	}
 }
 
+// NewTypedPropertyValue returns a new Basic with a datatype and a language
+// tag, either of them can be empty.
+func NewTypedPropertyValue(prd, val, valType, lang string) *BasicPropertyValue {
+	return &BasicPropertyValue{
+		&PropertyValue{
+			val:     val,
+			prd:     prd,
+			valType: valType,
+			lang:    lang,
+		},
+	}
+}
+
 // Definition represents a textual definition of an ontology term.
 type Definition struct {
 	*PropertyValue
@@ -10,6 +10,7 @@ type OboJSON struct {
 // OboJSONGraph models the graph section of OBO graph.
 type OboJSONGraph struct {
 	ID                  string        `json:"id"`
+	Lbl                 string        `json:"lbl,omitempty"`
 	Edges               []*JSONEdge   `json:"edges"`
 	Nodes               []*JSONNode   `json:"nodes"`
 	Meta                *JSONMeta     `json:"meta"`
@@ -73,6 +74,8 @@ type JSONSynonym struct {
 
 // JSONProperty models the properties of the nodes.
 type JSONProperty struct {
-	Pred string `json:"pred"`
-	Val  string `json:"val"`
+	Pred    string `json:"pred"`
+	Val     string `json:"val"`
+	ValType string `json:"valType,omitempty"`
+	Lang    string `json:"lang,omitempty"`
 }
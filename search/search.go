@@ -0,0 +1,315 @@
+// Package search provides an in memory full text search over the terms of an
+// OBO Graph. The labels, synonyms, definitions and comments of the terms are
+// tokenised and stemmed, and the matching terms are ranked with BM25F.
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/graph"
+)
+
+// Field is a searchable text field of a term.
+type Field int
+
+const (
+	// Label is the label of a term.
+	Label Field = iota
+	// Synonym is any synonym of a term.
+	Synonym
+	// Definition is the definition of a term.
+	Definition
+	// Comment is any comment of a term.
+	Comment
+	numFields
+)
+
+// String returns the name of the field.
+func (f Field) String() string {
+	return [...]string{"label", "synonym", "definition", "comment"}[f]
+}
+
+// Options configures an Index, the zero values are replaced by the defaults.
+type Options struct {
+	// K1 controls the saturation of the term frequency, 1.2 by default
+	K1 float64
+	// B controls the normalisation by the field length, 0.75 by default
+	B float64
+	// Weights are the weights of the fields, by default 3 for labels, 2 for
+	// synonyms, 1 for definitions and 0.5 for comments
+	Weights map[Field]float64
+	// IncludeDeprecated indexes the deprecated terms too
+	IncludeDeprecated bool
+	// Pre and Post are the markers around the highlighted words, <em> and
+	// </em> by default
+	Pre  string
+	Post string
+}
+
+// Highlight is a text of a term with the matched words marked. The text is
+// HTML escaped and the markers are inserted as they are, so that it can be
+// embedded in HTML with the default markers.
+type Highlight struct {
+	Field Field
+	Text  string
+}
+
+// Hit is a term matching a query.
+type Hit struct {
+	Term       graph.Term
+	Score      float64
+	Highlights []*Highlight
+}
+
+type document struct {
+	term   graph.Term
+	texts  [numFields][]string
+	length [numFields]int
+}
+
+type posting struct {
+	doc int
+	tf  [numFields]int
+}
+
+// Index is a full text index of the terms of a graph. It reflects the graph
+// at the time it was built and is safe for concurrent searches.
+type Index struct {
+	opt      Options
+	weights  [numFields]float64
+	docs     []*document
+	postings map[string][]*posting
+	avgLen   [numFields]float64
+	// words are the distinct lower cased words, sorted, for completing the
+	// last word of a query
+	words    []string
+	wordStem map[string]string
+}
+
+// NewIndex indexes the terms of the graph, nil options use the defaults.
+func NewIndex(grph graph.OboGraph, opt *Options) *Index {
+	idx := &Index{
+		opt:      defaultOptions(opt),
+		postings: make(map[string][]*posting),
+		wordStem: make(map[string]string),
+	}
+	for fld := Label; fld < numFields; fld++ {
+		idx.weights[fld] = idx.opt.Weights[fld]
+	}
+	var total [numFields]int
+	grph.EachTerm(func(trm graph.Term) bool {
+		if trm.RdfType() != "CLASS" || (trm.IsDeprecated() && !idx.opt.IncludeDeprecated) {
+			return true
+		}
+		doc := newDocument(trm)
+		var pst *posting
+		seen := make(map[string]*posting)
+		for fld := Label; fld < numFields; fld++ {
+			for _, text := range doc.texts[fld] {
+				for _, tkn := range tokenize(text) {
+					doc.length[fld]++
+					idx.addWord(strings.ToLower(text[tkn.start:tkn.end]), tkn.stem)
+					if pst = seen[tkn.stem]; pst == nil {
+						pst = &posting{doc: len(idx.docs)}
+						seen[tkn.stem] = pst
+						idx.postings[tkn.stem] = append(idx.postings[tkn.stem], pst)
+					}
+					pst.tf[fld]++
+				}
+			}
+			total[fld] += doc.length[fld]
+		}
+		idx.docs = append(idx.docs, doc)
+
+		return true
+	})
+	for fld := Label; fld < numFields; fld++ {
+		if len(idx.docs) > 0 {
+			idx.avgLen[fld] = float64(total[fld]) / float64(len(idx.docs))
+		}
+	}
+	idx.words = make([]string, 0, len(idx.wordStem))
+	for word := range idx.wordStem {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+
+	return idx
+}
+
+// Search returns the terms matching any word of the query, best first. Unless
+// the query ends with a space, its last word also matches the words it is a
+// prefix of, so that the query can be completed while typing. A
+// non-positive limit returns all hits.
+func (i *Index) Search(query string, limit int) []*Hit {
+	slots := i.querySlots(query)
+	scores := make(map[int]float64)
+	matched := make(map[int]map[string]bool)
+	for _, slot := range slots {
+		best := make(map[int]float64)
+		for stem, weight := range slot {
+			pst := i.postings[stem]
+			idf := i.idf(len(pst))
+			for _, p := range pst {
+				score := weight * idf * i.termScore(p)
+				if score > best[p.doc] {
+					best[p.doc] = score
+				}
+				if matched[p.doc] == nil {
+					matched[p.doc] = make(map[string]bool)
+				}
+				matched[p.doc][stem] = true
+			}
+		}
+		for doc, score := range best {
+			scores[doc] += score
+		}
+	}
+	hits := make([]*Hit, 0, len(scores))
+	order := make(map[*Hit]int)
+	for doc, score := range scores {
+		hit := &Hit{Term: i.docs[doc].term, Score: score}
+		order[hit] = doc
+		hits = append(hits, hit)
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+
+		return order[hits[a]] < order[hits[b]]
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	for _, hit := range hits {
+		hit.Highlights = i.highlights(i.docs[order[hit]], matched[order[hit]])
+	}
+
+	return hits
+}
+
+// querySlots returns the stems to look up for every word of the query along
+// with their weights, the completions of the last word are weighted lower
+// than the word itself.
+func (i *Index) querySlots(query string) []map[string]float64 {
+	tkns := tokenize(query)
+	slots := make([]map[string]float64, 0, len(tkns))
+	for _, tkn := range tkns {
+		slots = append(slots, map[string]float64{tkn.stem: 1})
+	}
+	if len(tkns) == 0 {
+		return slots
+	}
+	last := tkns[len(tkns)-1]
+	word := strings.ToLower(query[last.start:last.end])
+	if last.end < len(query) || !isPrefixable(word) {
+		return slots
+	}
+	slot := slots[len(slots)-1]
+	for j := sort.SearchStrings(i.words, word); j < len(i.words); j++ {
+		if !strings.HasPrefix(i.words[j], word) {
+			break
+		}
+		if stem := i.wordStem[i.words[j]]; slot[stem] == 0 {
+			slot[stem] = 0.5
+		}
+	}
+
+	return slots
+}
+
+// termScore is the BM25F score of a term in a document without its idf.
+func (i *Index) termScore(pst *posting) float64 {
+	doc := i.docs[pst.doc]
+	var wtf float64
+	for fld := Label; fld < numFields; fld++ {
+		if pst.tf[fld] == 0 {
+			continue
+		}
+		norm := 1.0
+		if i.avgLen[fld] > 0 {
+			norm = 1 - i.opt.B + i.opt.B*float64(doc.length[fld])/i.avgLen[fld]
+		}
+		wtf += i.weights[fld] * float64(pst.tf[fld]) / norm
+	}
+
+	return wtf * (i.opt.K1 + 1) / (wtf + i.opt.K1)
+}
+
+func (i *Index) idf(docFreq int) float64 {
+	return math.Log(1 + (float64(len(i.docs))-float64(docFreq)+0.5)/(float64(docFreq)+0.5))
+}
+
+func (i *Index) highlights(doc *document, stems map[string]bool) []*Highlight {
+	hlt := make([]*Highlight, 0)
+	for fld := Label; fld < numFields; fld++ {
+		for _, text := range doc.texts[fld] {
+			spans := make([][2]int, 0)
+			for _, tkn := range tokenize(text) {
+				if stems[tkn.stem] {
+					spans = append(spans, [2]int{tkn.start, tkn.end})
+				}
+			}
+			if len(spans) > 0 {
+				hlt = append(hlt, &Highlight{
+					Field: fld,
+					Text:  highlight(text, spans, i.opt.Pre, i.opt.Post),
+				})
+			}
+		}
+	}
+
+	return hlt
+}
+
+func (i *Index) addWord(word, stem string) {
+	if _, ok := i.wordStem[word]; !ok {
+		i.wordStem[word] = stem
+	}
+}
+
+func newDocument(trm graph.Term) *document {
+	doc := &document{term: trm}
+	if len(trm.Label()) > 0 {
+		doc.texts[Label] = []string{trm.Label()}
+	}
+	if !trm.HasMeta() {
+		return doc
+	}
+	mta := trm.Meta()
+	for _, syn := range mta.Synonyms() {
+		doc.texts[Synonym] = append(doc.texts[Synonym], syn.Value())
+	}
+	if def := mta.Definition(); def != nil && len(def.Value()) > 0 {
+		doc.texts[Definition] = []string{def.Value()}
+	}
+	doc.texts[Comment] = append(doc.texts[Comment], mta.Comments()...)
+
+	return doc
+}
+
+func defaultOptions(opt *Options) Options {
+	dop := Options{}
+	if opt != nil {
+		dop = *opt
+	}
+	if dop.K1 == 0 {
+		dop.K1 = 1.2
+	}
+	if dop.B == 0 {
+		dop.B = 0.75
+	}
+	weights := map[Field]float64{Label: 3, Synonym: 2, Definition: 1, Comment: 0.5}
+	for fld, wgt := range dop.Weights {
+		weights[fld] = wgt
+	}
+	dop.Weights = weights
+	if len(dop.Pre) == 0 && len(dop.Post) == 0 {
+		dop.Pre, dop.Post = "<em>", "</em>"
+	}
+
+	return dop
+}
@@ -0,0 +1,155 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGraph(assert *require.Assertions) graph.OboGraph {
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(
+		filepath.Join(
+			filepath.Dir(dir), "testdata", "so.json",
+		),
+	)
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+
+	return grph
+}
+
+func hitIDs(hits []*Hit) []graph.NodeID {
+	ids := make([]graph.NodeID, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.Term.ID())
+	}
+
+	return ids
+}
+
+func TestStem(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	cases := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"agreed":         "agre",
+		"hopping":        "hop",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"electrical":     "electr",
+		"adjustment":     "adjust",
+		"controll":       "control",
+		"genes":          "gene",
+		"coding":         "code",
+		"rna":            "rna",
+		"5s":             "5s",
+	}
+	for word, stem := range cases {
+		assert.Equalf(stem, Stem(word), "expect stem of %s", word)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	tkns := tokenize("The protein_coding genes of a cell")
+	stems := make([]string, 0, len(tkns))
+	for _, tkn := range tkns {
+		stems = append(stems, tkn.stem)
+	}
+	assert.Equal([]string{"protein", "code", "gene", "cell"}, stems)
+	assert.Equal(
+		"The <b>protein</b>_coding genes",
+		highlight("The protein_coding genes", [][2]int{{4, 11}}, "<b>", "</b>"),
+	)
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	idx := NewIndex(grph, nil)
+	hits := idx.Search("protein coding gene ", 5)
+	assert.Len(hits, 5, "expect the hits to be limited")
+	assert.Contains(hitIDs(hits), graph.NodeID("SO_0001217"), "expect protein_coding_gene among the best hits")
+	for i := 1; i < len(hits); i++ {
+		assert.GreaterOrEqual(hits[i-1].Score, hits[i].Score, "expect the hits to be sorted by score")
+	}
+	top := hits[0]
+	assert.NotEmpty(top.Highlights, "expect highlighted texts")
+	assert.Contains(top.Highlights[0].Text, "<em>", "expect the matched words to be marked")
+	assert.Equal("label", Label.String(), "expect the name of the field")
+
+	genes := idx.Search("genes ", 0)
+	assert.NotEmpty(genes, "expect the stemmed word to match")
+	for _, h := range genes {
+		assert.False(h.Term.IsDeprecated(), "expect no deprecated term by default")
+	}
+	assert.Empty(idx.Search("the of and", 0), "expect no hit for stop words")
+	assert.Empty(idx.Search("zzzzqqq ", 0), "expect no hit for unknown words")
+}
+
+func TestSearchPrefix(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	idx := NewIndex(buildGraph(assert), nil)
+	assert.Empty(idx.Search("transcrip ", 0), "expect no completion after a space")
+	hits := idx.Search("transcrip", 10)
+	assert.NotEmpty(hits, "expect the last word to be completed")
+	for _, h := range hits {
+		var marked bool
+		for _, hlt := range h.Highlights {
+			marked = marked || strings.Contains(strings.ToLower(hlt.Text), "<em>transcript")
+		}
+		assert.Truef(marked, "expect a completion of the word to be marked in %s", h.Term.ID())
+	}
+}
+
+func TestSearchOptions(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	dflt := NewIndex(grph, nil)
+	all := NewIndex(grph, &Options{IncludeDeprecated: true, Pre: "[", Post: "]"})
+	assert.Greater(len(all.docs), len(dflt.docs), "expect the deprecated terms to be indexed")
+	hits := all.Search("intron ", 1)
+	assert.Len(hits, 1, "expect a single hit")
+	assert.Contains(hits[0].Highlights[0].Text, "[", "expect the custom markers")
+	lbl := NewIndex(grph, &Options{Weights: map[Field]float64{Definition: 0, Comment: 0, Synonym: 0}})
+	for _, h := range lbl.Search("intron ", 0) {
+		assert.Contains(strings.ToLower(h.Term.Label()), "intron", "expect only the labels to score")
+	}
+}
+
+func TestSearchHighlightEscape(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	grph.AddTerm(graph.NewTermWithMeta(
+		"TEST_0000001",
+		model.NewMeta(&model.MetaOptions{
+			Definition: model.NewDefinition("A <b>quuxase</b> & friends.", nil),
+		}),
+		"CLASS", "test term", "http://purl.obolibrary.org/obo/TEST_0000001",
+	))
+	hits := NewIndex(grph, nil).Search("quuxase ", 0)
+	assert.Len(hits, 1, "expect a single hit")
+	assert.Equal(
+		"A &lt;b&gt;<em>quuxase</em>&lt;/b&gt; &amp; friends.",
+		hits[0].Highlights[0].Text,
+		"expect the text to be escaped around the markers",
+	)
+}
@@ -0,0 +1,244 @@
+package search
+
+type suffix struct {
+	from string
+	to   string
+}
+
+var step2Suffixes = []suffix{
+	{"ational", "ate"}, {"tional", "tion"},
+	{"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"},
+	{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+	{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"},
+	{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"},
+	{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+var step3Suffixes = []suffix{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var step4Suffixes = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+// Stem reduces an english word to its stem with the Porter stemming
+// algorithm. The word is expected in lower case, words with other than
+// ASCII letters are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	stm := &stemmer{b: []byte(word), k: len(word) - 1}
+	stm.step1ab()
+	if stm.k > 1 {
+		stm.step1c()
+		stm.replace(step2Suffixes)
+		stm.replace(step3Suffixes)
+		stm.step4()
+		stm.step5()
+	}
+
+	return string(stm.b[:stm.k+1])
+}
+
+// stemmer keeps the word being stemmed in b[0..k], j marks the end of the
+// stem when a suffix is matched.
+type stemmer struct {
+	b []byte
+	k int
+	j int
+}
+
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	}
+
+	return true
+}
+
+// m measures the number of consonant sequences in b[0..j].
+func (s *stemmer) m() int {
+	cnt, i := 0, 0
+	for {
+		if i > s.j {
+			return cnt
+		}
+		if !s.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > s.j {
+				return cnt
+			}
+			if s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		cnt++
+		for {
+			if i > s.j {
+				return cnt
+			}
+			if !s.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+func (s *stemmer) vowelInStem() bool {
+	for i := 0; i <= s.j; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *stemmer) doubleCons(i int) bool {
+	return i >= 1 && s.b[i] == s.b[i-1] && s.cons(i)
+}
+
+// cvc checks for consonant-vowel-consonant ending at i where the last
+// consonant is not w, x or y.
+func (s *stemmer) cvc(i int) bool {
+	if i < 2 || !s.cons(i) || s.cons(i-1) || !s.cons(i-2) {
+		return false
+	}
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+
+	return true
+}
+
+func (s *stemmer) ends(sfx string) bool {
+	size := len(sfx)
+	if size > s.k+1 || string(s.b[s.k-size+1:s.k+1]) != sfx {
+		return false
+	}
+	s.j = s.k - size
+
+	return true
+}
+
+func (s *stemmer) setTo(rep string) {
+	s.b = append(s.b[:s.j+1], rep...)
+	s.k = s.j + len(rep)
+}
+
+func (s *stemmer) step1ab() {
+	if s.b[s.k] == 's' {
+		switch {
+		case s.ends("sses"):
+			s.k -= 2
+		case s.ends("ies"):
+			s.setTo("i")
+		case s.b[s.k-1] != 's':
+			s.k--
+		}
+	}
+	if s.ends("eed") {
+		if s.m() > 0 {
+			s.k--
+		}
+
+		return
+	}
+	if !(s.ends("ed") || s.ends("ing")) || !s.vowelInStem() {
+		return
+	}
+	s.k = s.j
+	switch {
+	case s.ends("at"):
+		s.setTo("ate")
+	case s.ends("bl"):
+		s.setTo("ble")
+	case s.ends("iz"):
+		s.setTo("ize")
+	case s.doubleCons(s.k):
+		s.k--
+		switch s.b[s.k] {
+		case 'l', 's', 'z':
+			s.k++
+		}
+	default:
+		s.j = s.k
+		if s.m() == 1 && s.cvc(s.k) {
+			s.setTo("e")
+		}
+	}
+}
+
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.vowelInStem() {
+		s.b[s.k] = 'i'
+	}
+}
+
+// replace replaces the first matching suffix when the stem has a positive
+// measure.
+func (s *stemmer) replace(sfxs []suffix) {
+	for _, sfx := range sfxs {
+		if s.ends(sfx.from) {
+			if s.m() > 0 {
+				s.setTo(sfx.to)
+			}
+
+			return
+		}
+	}
+}
+
+func (s *stemmer) step4() {
+	for _, sfx := range step4Suffixes {
+		if !s.ends(sfx) {
+			continue
+		}
+		if sfx == "ion" && (s.j < 0 || (s.b[s.j] != 's' && s.b[s.j] != 't')) {
+			continue
+		}
+		if s.m() > 1 {
+			s.k = s.j
+		}
+
+		return
+	}
+}
+
+func (s *stemmer) step5() {
+	s.j = s.k
+	if s.b[s.k] == 'e' {
+		msr := s.m()
+		if msr > 1 || (msr == 1 && !s.cvc(s.k-1)) {
+			s.k--
+		}
+	}
+	if s.b[s.k] == 'l' && s.doubleCons(s.k) && s.m() > 1 {
+		s.k--
+	}
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "which": true, "with": true,
+}
+
+// token is a word of a text along with its stem and its byte offsets.
+type token struct {
+	stem  string
+	start int
+	end   int
+}
+
+// tokenize splits a text into the stems of its words, the words are the
+// runs of letters and digits, so underscores and punctuation separate them.
+// Stop words are dropped.
+func tokenize(text string) []token {
+	tkns := make([]token, 0)
+	start := -1
+	for pos, rne := range text {
+		if unicode.IsLetter(rne) || unicode.IsDigit(rne) {
+			if start < 0 {
+				start = pos
+			}
+
+			continue
+		}
+		if start >= 0 {
+			tkns = appendToken(tkns, text, start, pos)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tkns = appendToken(tkns, text, start, len(text))
+	}
+
+	return tkns
+}
+
+func appendToken(tkns []token, text string, start, end int) []token {
+	word := strings.ToLower(text[start:end])
+	if stopWords[word] {
+		return tkns
+	}
+
+	return append(tkns, token{stem: Stem(word), start: start, end: end})
+}
+
+// highlight wraps the parts of the text between the given offsets with the
+// markers, the offsets are sorted and do not overlap.
+func highlight(text string, spans [][2]int, pre, post string) string {
+	var bld strings.Builder
+	last := 0
+	for _, spn := range spans {
+		bld.WriteString(html.EscapeString(text[last:spn[0]]))
+		bld.WriteString(pre)
+		bld.WriteString(html.EscapeString(text[spn[0]:spn[1]]))
+		bld.WriteString(post)
+		last = spn[1]
+	}
+	bld.WriteString(html.EscapeString(text[last:]))
+
+	return bld.String()
+}
+
+// isPrefixable tells whether a query word is long enough to be completed.
+func isPrefixable(word string) bool {
+	return utf8.RuneCountInString(word) >= 2
+}
@@ -0,0 +1,163 @@
+package similarity
+
+import (
+	"github.com/dictyBase/go-obograph/graph"
+)
+
+// Resnik returns the IC of the most informative common ancestor of two
+// terms.
+func (c *Calculator) Resnik(id1, id2 graph.NodeID) float64 {
+	_, ic := c.MICA(id1, id2)
+
+	return ic
+}
+
+// Lin returns the Resnik similarity normalised by the IC of both terms, in
+// the range of 0 to 1.
+func (c *Calculator) Lin(id1, id2 graph.NodeID) float64 {
+	sum := c.IC(id1) + c.IC(id2)
+	if sum == 0 {
+		return 0
+	}
+
+	return 2 * c.Resnik(id1, id2) / sum
+}
+
+// JiangConrathDistance returns the semantic distance between two terms,
+// IC(t1) + IC(t2) - 2 * IC(MICA).
+func (c *Calculator) JiangConrathDistance(id1, id2 graph.NodeID) float64 {
+	return c.IC(id1) + c.IC(id2) - 2*c.Resnik(id1, id2)
+}
+
+// JiangConrath returns the Jiang-Conrath distance converted into a
+// similarity, 1 / (1 + distance), in the range of 0 to 1.
+func (c *Calculator) JiangConrath(id1, id2 graph.NodeID) float64 {
+	if !c.known(id1) || !c.known(id2) {
+		return 0
+	}
+
+	return 1 / (1 + c.JiangConrathDistance(id1, id2))
+}
+
+// SimGIC returns the sum of IC of the ancestors shared by two terms divided
+// by the sum of IC of all of their ancestors.
+func (c *Calculator) SimGIC(id1, id2 graph.NodeID) float64 {
+	return c.simGIC(c.anc[id1], c.anc[id2])
+}
+
+// Jaccard returns the number of ancestors shared by two terms divided by the
+// number of all of their ancestors.
+func (c *Calculator) Jaccard(id1, id2 graph.NodeID) float64 {
+	return jaccard(c.anc[id1], c.anc[id2])
+}
+
+// ResnikSet returns the best match average of Resnik similarity between two
+// sets of terms.
+func (c *Calculator) ResnikSet(set1, set2 []graph.NodeID) float64 {
+	return bestMatchAverage(set1, set2, c.Resnik)
+}
+
+// LinSet returns the best match average of Lin similarity between two sets
+// of terms.
+func (c *Calculator) LinSet(set1, set2 []graph.NodeID) float64 {
+	return bestMatchAverage(set1, set2, c.Lin)
+}
+
+// JiangConrathSet returns the best match average of Jiang-Conrath similarity
+// between two sets of terms.
+func (c *Calculator) JiangConrathSet(set1, set2 []graph.NodeID) float64 {
+	return bestMatchAverage(set1, set2, c.JiangConrath)
+}
+
+// SimGICSet returns the SimGIC similarity between the combined ancestors of
+// two sets of terms.
+func (c *Calculator) SimGICSet(set1, set2 []graph.NodeID) float64 {
+	return c.simGIC(c.setClosure(set1), c.setClosure(set2))
+}
+
+// JaccardSet returns the Jaccard similarity between the combined ancestors
+// of two sets of terms.
+func (c *Calculator) JaccardSet(set1, set2 []graph.NodeID) float64 {
+	return jaccard(c.setClosure(set1), c.setClosure(set2))
+}
+
+func (c *Calculator) known(id graph.NodeID) bool {
+	_, ok := c.anc[id]
+
+	return ok
+}
+
+func (c *Calculator) setClosure(ids []graph.NodeID) closure {
+	anc := make(closure)
+	for _, id := range ids {
+		for aid := range c.anc[id] {
+			anc[aid] = true
+		}
+	}
+
+	return anc
+}
+
+func (c *Calculator) simGIC(anc1, anc2 closure) float64 {
+	var shared, all float64
+	for id := range anc1 {
+		all += c.ic[id]
+		if anc2[id] {
+			shared += c.ic[id]
+		}
+	}
+	for id := range anc2 {
+		if !anc1[id] {
+			all += c.ic[id]
+		}
+	}
+	if all == 0 {
+		return 0
+	}
+
+	return shared / all
+}
+
+func jaccard(anc1, anc2 closure) float64 {
+	shared := 0
+	for id := range anc1 {
+		if anc2[id] {
+			shared++
+		}
+	}
+	all := len(anc1) + len(anc2) - shared
+	if all == 0 {
+		return 0
+	}
+
+	return float64(shared) / float64(all)
+}
+
+func bestMatchAverage(
+	set1, set2 []graph.NodeID,
+	measure func(graph.NodeID, graph.NodeID) float64,
+) float64 {
+	if len(set1) == 0 || len(set2) == 0 {
+		return 0
+	}
+
+	return (bestMatch(set1, set2, measure) + bestMatch(set2, set1, measure)) / 2
+}
+
+func bestMatch(
+	from, to []graph.NodeID,
+	measure func(graph.NodeID, graph.NodeID) float64,
+) float64 {
+	var sum float64
+	for _, id1 := range from {
+		best := 0.0
+		for _, id2 := range to {
+			if score := measure(id1, id2); score > best {
+				best = score
+			}
+		}
+		sum += best
+	}
+
+	return sum / float64(len(from))
+}
@@ -0,0 +1,149 @@
+// Package similarity provides information content and semantic similarity
+// measures for terms and sets of terms in an OBO Graph.
+package similarity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dictyBase/go-obograph/graph"
+)
+
+type closure map[graph.NodeID]bool
+
+// Calculator computes information content(IC) of terms and the similarity
+// measures derived from it. It precomputes the ancestor closure of every
+// term, so it should be built once and reused. A Calculator is safe for
+// concurrent use once created.
+type Calculator struct {
+	grph  graph.OboGraph
+	preds []graph.NodeID
+	anc   map[graph.NodeID]closure
+	ic    map[graph.NodeID]float64
+}
+
+// NewIntrinsic is the constructor for a Calculator that uses intrinsic IC,
+// computed from the number of descendents of a term,
+// IC(t) = -log((descendents(t)+1)/N), where N is the number of non deprecated
+// classes in the graph. When predicates are given, only relationships with
+// those predicates are followed, otherwise all of them are.
+func NewIntrinsic(grph graph.OboGraph, preds ...graph.NodeID) *Calculator {
+	clc := newCalculator(grph, preds)
+	total := float64(len(clc.anc))
+	counts := make(map[graph.NodeID]int)
+	for _, anc := range clc.anc {
+		for id := range anc {
+			counts[id]++
+		}
+	}
+	for id := range clc.anc {
+		// counts includes the term itself
+		clc.ic[id] = -math.Log(float64(counts[id]) / total)
+	}
+
+	return clc
+}
+
+// NewCorpus is the constructor for a Calculator that uses corpus based IC,
+// computed from the annotation frequency of terms. Every annotation to a
+// term is also counted for all of its ancestors,
+// IC(t) = -log(count(t)/total annotations). Terms without any annotation get
+// an IC of zero.
+func NewCorpus(
+	grph graph.OboGraph,
+	freq map[graph.NodeID]int,
+	preds ...graph.NodeID,
+) (*Calculator, error) {
+	clc := newCalculator(grph, preds)
+	if len(freq) == 0 {
+		return nil, errors.New("term frequency is empty")
+	}
+	total := 0
+	counts := make(map[graph.NodeID]int)
+	for id, cnt := range freq {
+		anc, ok := clc.anc[id]
+		if !ok {
+			return nil, fmt.Errorf("term %s is not a class in the graph", id)
+		}
+		if cnt < 0 {
+			return nil, fmt.Errorf("term %s has negative frequency %d", id, cnt)
+		}
+		total += cnt
+		for aid := range anc {
+			counts[aid] += cnt
+		}
+	}
+	if total == 0 {
+		return nil, errors.New("term frequency has no annotations")
+	}
+	for id := range clc.anc {
+		if counts[id] > 0 {
+			clc.ic[id] = -math.Log(float64(counts[id]) / float64(total))
+		}
+	}
+
+	return clc, nil
+}
+
+func newCalculator(grph graph.OboGraph, preds []graph.NodeID) *Calculator {
+	clc := &Calculator{
+		grph:  grph,
+		preds: preds,
+		anc:   make(map[graph.NodeID]closure),
+		ic:    make(map[graph.NodeID]float64),
+	}
+	for _, trm := range grph.TermsByType("CLASS") {
+		if trm.IsDeprecated() {
+			continue
+		}
+		anc := closure{trm.ID(): true}
+		for _, atrm := range graph.AncestorsByPredicate(grph, trm.ID(), preds...) {
+			anc[atrm.ID()] = true
+		}
+		clc.anc[trm.ID()] = anc
+	}
+
+	return clc
+}
+
+// IC returns the information content of a term, zero for unknown terms.
+func (c *Calculator) IC(id graph.NodeID) float64 {
+	return c.ic[id]
+}
+
+// MICA returns the most informative common ancestor of two terms along with
+// its IC. A term is considered an ancestor of itself. An empty id is returned
+// when the terms have no common ancestor.
+func (c *Calculator) MICA(id1, id2 graph.NodeID) (graph.NodeID, float64) {
+	var mica graph.NodeID
+	best := -1.0
+	anc1, anc2 := c.anc[id1], c.anc[id2]
+	for id := range anc1 {
+		if !anc2[id] {
+			continue
+		}
+		// break ties by id to keep the result deterministic
+		if ic := c.ic[id]; ic > best || (ic == best && id < mica) {
+			mica, best = id, ic
+		}
+	}
+	if len(mica) == 0 {
+		return mica, 0
+	}
+
+	return mica, best
+}
+
+// Ancestors returns the ancestor closure of a term, including the term
+// itself, sorted by id.
+func (c *Calculator) Ancestors(id graph.NodeID) []graph.NodeID {
+	ids := make([]graph.NodeID, 0, len(c.anc[id]))
+	for aid := range c.anc[id] {
+		ids = append(ids, aid)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
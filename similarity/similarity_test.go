@@ -0,0 +1,127 @@
+package similarity
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGraph(assert *require.Assertions) graph.OboGraph {
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(
+		filepath.Join(
+			filepath.Dir(dir), "testdata", "so.json",
+		),
+	)
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+
+	return grph
+}
+
+func TestIntrinsicIC(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	clc := NewIntrinsic(buildGraph(assert), graph.NodeID("is_a"))
+	gene := graph.NodeID("SO_0000704")
+	pcgene := graph.NodeID("SO_0001217")
+	assert.Greater(clc.IC(gene), 0.0, "expect gene to have positive IC")
+	assert.Greater(
+		clc.IC(pcgene),
+		clc.IC(gene),
+		"expect child term to be more informative than its parent",
+	)
+	assert.Zero(clc.IC("SO_9999999"), "expect zero IC for unknown term")
+	assert.Contains(
+		clc.Ancestors(pcgene),
+		gene,
+		"expect gene to be an ancestor of protein coding gene",
+	)
+}
+
+func TestPairwiseSimilarity(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	clc := NewIntrinsic(buildGraph(assert), graph.NodeID("is_a"))
+	gene := graph.NodeID("SO_0000704")
+	child1 := graph.NodeID("SO_0001217")
+	child2 := graph.NodeID("SO_0001263")
+	mica, ic := clc.MICA(child1, child2)
+	assert.Equal(gene, mica, "expect gene to be the MICA")
+	assert.InDelta(clc.IC(gene), ic, 1e-9, "expect MICA IC to match")
+	assert.InDelta(clc.IC(child1), clc.Resnik(child1, child1), 1e-9)
+	assert.InDelta(1.0, clc.Lin(child1, child1), 1e-9)
+	assert.InDelta(1.0, clc.JiangConrath(child1, child1), 1e-9)
+	assert.InDelta(1.0, clc.SimGIC(child1, child1), 1e-9)
+	assert.InDelta(1.0, clc.Jaccard(child1, child1), 1e-9)
+	for _, score := range []float64{
+		clc.Lin(child1, child2),
+		clc.JiangConrath(child1, child2),
+		clc.SimGIC(child1, child2),
+		clc.Jaccard(child1, child2),
+	} {
+		assert.Greater(score, 0.0, "expect similarity above zero")
+		assert.Less(score, 1.0, "expect similarity below one")
+	}
+	assert.InDelta(
+		clc.Lin(child1, child2),
+		clc.Lin(child2, child1),
+		1e-9,
+		"expect Lin similarity to be symmetric",
+	)
+}
+
+func TestSetSimilarity(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	clc := NewIntrinsic(buildGraph(assert), graph.NodeID("is_a"))
+	set1 := []graph.NodeID{"SO_0001217", "SO_0000340"}
+	set2 := []graph.NodeID{"SO_0001263", "SO_0000340"}
+	for name, fn := range map[string]func([]graph.NodeID, []graph.NodeID) float64{
+		"resnik":       clc.ResnikSet,
+		"lin":          clc.LinSet,
+		"jiangconrath": clc.JiangConrathSet,
+		"simgic":       clc.SimGICSet,
+		"jaccard":      clc.JaccardSet,
+	} {
+		assert.Greaterf(fn(set1, set2), 0.0, "expect %s above zero", name)
+		assert.InDeltaf(fn(set1, set2), fn(set2, set1), 1e-9, "expect %s to be symmetric", name)
+		assert.Zerof(fn(set1, nil), "expect %s of empty set to be zero", name)
+	}
+	assert.InDelta(1.0, clc.LinSet(set1, set1), 1e-9)
+}
+
+func TestCorpusIC(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	gene := graph.NodeID("SO_0000704")
+	pcgene := graph.NodeID("SO_0001217")
+	clc, err := NewCorpus(
+		grph,
+		map[graph.NodeID]int{pcgene: 3, "SO_0001263": 1},
+		graph.NodeID("is_a"),
+	)
+	assert.NoError(err, "expect no error from corpus IC")
+	assert.Zero(clc.IC(gene), "expect gene to have all annotations")
+	assert.InDelta(-math.Log(0.75), clc.IC(pcgene), 1e-9)
+	clc, err = NewCorpus(grph, map[graph.NodeID]int{})
+	assert.Error(err, "expect error from empty frequency")
+	assert.Nil(clc, "expect no calculator with an error")
+	clc, err = NewCorpus(grph, map[graph.NodeID]int{"SO_9999999": 1})
+	assert.Error(err, "expect error from unknown term")
+	assert.Nil(clc, "expect no calculator with an error")
+	clc, err = NewCorpus(grph, map[graph.NodeID]int{pcgene: -1})
+	assert.Error(err, "expect error from negative frequency")
+	assert.Nil(clc, "expect no calculator with an error")
+	clc, err = NewCorpus(grph, map[graph.NodeID]int{pcgene: 0})
+	assert.Error(err, "expect error from frequency without annotations")
+	assert.Nil(clc, "expect no calculator with an error")
+}
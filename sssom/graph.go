@@ -0,0 +1,303 @@
+package sssom
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/dictyBase/go-obograph/model"
+)
+
+const oboPrefix = "http://purl.obolibrary.org/obo/"
+
+// DbXref is the predicate of the mappings derived from xrefs.
+const DbXref = "oboInOwl:hasDbXref"
+
+// builtinPrefixes are the prefixes of the predicates and justifications of
+// the mappings, they need not be in the curie map of a mapping set.
+var builtinPrefixes = map[string]string{
+	"oboInOwl": "http://www.geneontology.org/formats/oboInOwl#",
+	"owl":      "http://www.w3.org/2002/07/owl#",
+	"rdfs":     "http://www.w3.org/2000/01/rdf-schema#",
+	"semapv":   "https://w3id.org/semapv/vocab/",
+	"skos":     "http://www.w3.org/2004/02/skos/core#",
+}
+
+// mappingPredicates are the properties of terms that are mappings.
+var mappingPredicates = map[string]string{
+	model.DbXrefIRI:       DbXref,
+	model.ExactMatchIRI:   "skos:exactMatch",
+	model.CloseMatchIRI:   "skos:closeMatch",
+	model.BroadMatchIRI:   "skos:broadMatch",
+	model.NarrowMatchIRI:  "skos:narrowMatch",
+	model.RelatedMatchIRI: "skos:relatedMatch",
+}
+
+// inversePredicates relate the object of a mapping back to its subject, the
+// symmetric predicates are their own inverse.
+var inversePredicates = map[string]string{
+	DbXref:                "oboInOwl:hasDbXref",
+	"owl:equivalentClass": "owl:equivalentClass",
+	"skos:broadMatch":     "skos:narrowMatch",
+	"skos:closeMatch":     "skos:closeMatch",
+	"skos:exactMatch":     "skos:exactMatch",
+	"skos:narrowMatch":    "skos:broadMatch",
+	"skos:relatedMatch":   "skos:relatedMatch",
+}
+
+// Options configures the mapping set generated from a graph.
+type Options struct {
+	// MappingSetID defaults to the IRI of the graph
+	MappingSetID string
+	License      string
+	// Justification defaults to UnspecifiedMatching
+	Justification string
+	// CurieMap expands the prefixes of the xrefs, the prefixes without an
+	// expansion are left out of the curie map of the mapping set
+	CurieMap map[string]string
+	// IncludeDeprecated maps the deprecated terms too
+	IncludeDeprecated bool
+}
+
+// FromGraph generates a mapping set from the xrefs of the terms and their
+// hasDbXref and SKOS mapping properties. The xrefs that are URLs rather than
+// CURIEs are skipped.
+func FromGraph(grph graph.OboGraph, opt *Options) *MappingSet {
+	if opt == nil {
+		opt = &Options{}
+	}
+	set := &MappingSet{
+		Metadata: &Metadata{
+			MappingSetID: opt.MappingSetID,
+			License:      opt.License,
+			CurieMap:     make(map[string]string),
+		},
+		Mappings: make([]*Mapping, 0),
+	}
+	if len(set.Metadata.MappingSetID) == 0 {
+		set.Metadata.MappingSetID = grph.IRI()
+	}
+	if grph.Meta() != nil {
+		set.Metadata.MappingSetVersion = grph.Meta().Version()
+	}
+	jst := opt.Justification
+	if len(jst) == 0 {
+		jst = UnspecifiedMatching
+	}
+	seen := make(map[[3]string]bool)
+	add := func(trm graph.Term, pred, obj string) {
+		subj := curie(trm.ID())
+		key := [3]string{subj, pred, obj}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		set.Mappings = append(set.Mappings, &Mapping{
+			SubjectID:            subj,
+			SubjectLabel:         trm.Label(),
+			PredicateID:          pred,
+			ObjectID:             obj,
+			MappingJustification: jst,
+		})
+		set.addPrefix(subj, oboPrefix+prefix(subj)+"_")
+		set.addPrefix(pred, builtinPrefixes[prefix(pred)])
+		set.addPrefix(jst, builtinPrefixes[prefix(jst)])
+		set.addPrefix(obj, opt.CurieMap[prefix(obj)])
+	}
+	grph.EachTerm(func(trm graph.Term) bool {
+		if trm.RdfType() != "CLASS" || !trm.HasMeta() || (trm.IsDeprecated() && !opt.IncludeDeprecated) {
+			return true
+		}
+		for _, val := range trm.Meta().XrefsValues() {
+			if obj, ok := compact(val, opt.CurieMap); ok {
+				add(trm, DbXref, obj)
+			}
+		}
+		for _, prop := range trm.Meta().BasicPropertyValues() {
+			pred, ok := mappingPredicates[prop.Pred()]
+			if !ok {
+				continue
+			}
+			if obj, ok := compact(prop.Value(), opt.CurieMap); ok {
+				add(trm, pred, obj)
+			}
+		}
+
+		return true
+	})
+
+	return set
+}
+
+// ApplyResult tells which mappings were attached to the terms of a graph.
+type ApplyResult struct {
+	Applied []*Mapping
+	// Skipped are the mappings with neither the subject nor the object in
+	// the graph, or with only the object and a predicate without a known
+	// inverse
+	Skipped []*Mapping
+}
+
+// Apply attaches the mappings to the terms of the graph. A mapping is
+// attached to its subject, or to its object with the inverse predicate when
+// only the object is in the graph, which needs a predicate with a known
+// inverse. The hasDbXref mappings become xrefs and
+// the others basic property values with the expanded predicate. The values
+// already present are not repeated.
+func Apply(grph graph.OboGraph, set *MappingSet) *ApplyResult {
+	res := &ApplyResult{
+		Applied: make([]*Mapping, 0),
+		Skipped: make([]*Mapping, 0),
+	}
+	crm := make(map[string]string)
+	if set.Metadata != nil {
+		crm = set.Metadata.CurieMap
+	}
+	for _, mpg := range set.Mappings {
+		pred, other := mpg.PredicateID, mpg.ObjectID
+		trm := grph.GetTerm(graph.NormalizeID(mpg.SubjectID))
+		if trm == nil {
+			inv, ok := inversePredicates[pred]
+			if ok {
+				trm = grph.GetTerm(graph.NormalizeID(mpg.ObjectID))
+			}
+			pred, other = inv, mpg.SubjectID
+		}
+		if trm == nil {
+			res.Skipped = append(res.Skipped, mpg)
+
+			continue
+		}
+		opt := &model.MetaOptions{}
+		if trm.HasMeta() {
+			opt = trm.Meta().Options()
+		}
+		if pred == DbXref {
+			if !hasXref(opt.Xrefs, other) {
+				opt.Xrefs = append(opt.Xrefs, model.NewXref(other))
+			}
+		} else {
+			iri := expand(pred, crm)
+			if !hasProperty(opt.BaseProps, iri, other) {
+				opt.BaseProps = append(opt.BaseProps, model.NewBasicPropertyValue(iri, other))
+			}
+		}
+		err := grph.UpdateTerm(graph.NewTermWithMeta(
+			trm.ID(), model.NewMeta(opt), trm.RdfType(), trm.Label(), trm.IRI(),
+		))
+		if err != nil {
+			res.Skipped = append(res.Skipped, mpg)
+
+			continue
+		}
+		res.Applied = append(res.Applied, mpg)
+	}
+
+	return res
+}
+
+// Prefixes returns the prefixes used by the mappings that are neither in the
+// curie map nor builtin, sorted.
+func (s *MappingSet) Prefixes() []string {
+	seen := make(map[string]bool)
+	for _, mpg := range s.Mappings {
+		for _, val := range []string{mpg.SubjectID, mpg.PredicateID, mpg.ObjectID, mpg.MappingJustification} {
+			pfx := prefix(val)
+			if len(pfx) == 0 || len(builtinPrefixes[pfx]) > 0 {
+				continue
+			}
+			if s.Metadata == nil || len(s.Metadata.CurieMap[pfx]) == 0 {
+				seen[pfx] = true
+			}
+		}
+	}
+	pfx := make([]string, 0, len(seen))
+	for val := range seen {
+		pfx = append(pfx, val)
+	}
+	sort.Strings(pfx)
+
+	return pfx
+}
+
+func (s *MappingSet) addPrefix(crie, iri string) {
+	pfx := prefix(crie)
+	if len(pfx) == 0 || len(iri) == 0 {
+		return
+	}
+	if _, ok := s.Metadata.CurieMap[pfx]; !ok {
+		s.Metadata.CurieMap[pfx] = iri
+	}
+}
+
+// compact converts an xref to a CURIE, the OBO PURLs and the IRIs starting
+// with an expansion of the curie map are compacted.
+func compact(val string, crm map[string]string) (string, bool) {
+	val = strings.TrimSpace(val)
+	pfx := graph.XrefPrefix(val)
+	if len(pfx) > 0 && pfx != graph.URLPrefix {
+		return val, true
+	}
+	if strings.HasPrefix(val, oboPrefix) {
+		return curie(graph.NodeID(strings.TrimPrefix(val, oboPrefix))), true
+	}
+	var best string
+	for pfx, iri := range crm {
+		if strings.HasPrefix(val, iri) && len(iri) > len(crm[best]) {
+			best = pfx
+		}
+	}
+	if len(best) == 0 {
+		return "", false
+	}
+
+	return best + ":" + strings.TrimPrefix(val, crm[best]), true
+}
+
+// expand converts a CURIE to an IRI with the curie map or the builtin
+// prefixes, an unknown prefix leaves it as it is.
+func expand(crie string, crm map[string]string) string {
+	pfx := prefix(crie)
+	iri, ok := crm[pfx]
+	if !ok {
+		iri, ok = builtinPrefixes[pfx]
+	}
+	if !ok {
+		return crie
+	}
+
+	return iri + strings.TrimPrefix(crie, pfx+":")
+}
+
+func curie(idn graph.NodeID) string {
+	return strings.Replace(string(idn), "_", ":", 1)
+}
+
+func prefix(crie string) string {
+	pos := strings.Index(crie, ":")
+	if pos < 0 {
+		return ""
+	}
+
+	return crie[:pos]
+}
+
+func hasXref(xrefs []*model.Xref, val string) bool {
+	for _, xrf := range xrefs {
+		if xrf.Value() == val {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasProperty(props []*model.BasicPropertyValue, pred, val string) bool {
+	for _, prop := range props {
+		if prop.Pred() == pred && prop.Value() == val {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,307 @@
+// Package sssom reads and writes mappings between ontologies in the SSSOM
+// TSV format, generates them from the xrefs of an OBO Graph and attaches them
+// back to its terms.
+package sssom
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Columns of a SSSOM TSV mapping set.
+const (
+	SubjectID            = "subject_id"
+	SubjectLabel         = "subject_label"
+	PredicateID          = "predicate_id"
+	ObjectID             = "object_id"
+	ObjectLabel          = "object_label"
+	MappingJustification = "mapping_justification"
+	Confidence           = "confidence"
+	Comment              = "comment"
+)
+
+// UnspecifiedMatching is the justification of a mapping with no recorded
+// evidence, such as one derived from an xref.
+const UnspecifiedMatching = "semapv:UnspecifiedMatching"
+
+var (
+	columns  = []string{SubjectID, SubjectLabel, PredicateID, ObjectID, ObjectLabel, MappingJustification, Confidence, Comment}
+	required = []string{SubjectID, PredicateID, ObjectID, MappingJustification}
+)
+
+// Metadata is the metadata block of a mapping set.
+type Metadata struct {
+	MappingSetID      string            `yaml:"mapping_set_id,omitempty"`
+	MappingSetVersion string            `yaml:"mapping_set_version,omitempty"`
+	License           string            `yaml:"license,omitempty"`
+	MappingDate       string            `yaml:"mapping_date,omitempty"`
+	CurieMap          map[string]string `yaml:"curie_map,omitempty"`
+	// Extra has the other metadata elements
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// Mapping is a row of a mapping set.
+type Mapping struct {
+	SubjectID            string
+	SubjectLabel         string
+	PredicateID          string
+	ObjectID             string
+	ObjectLabel          string
+	MappingJustification string
+	// Confidence is nil when not given
+	Confidence *float64
+	Comment    string
+	// Extra has the values of the other columns
+	Extra map[string]string
+}
+
+// MappingSet is a set of mappings along with its metadata.
+type MappingSet struct {
+	Metadata *Metadata
+	Mappings []*Mapping
+}
+
+// Read reads a mapping set in SSSOM TSV format with its metadata embedded
+// as a YAML block of lines starting with #. The values are separated by tabs
+// and never quoted. Every row has a value for each column of the header, the
+// required ones not being empty.
+func Read(r io.Reader) (*MappingSet, error) {
+	set := &MappingSet{Metadata: &Metadata{}, Mappings: make([]*Mapping, 0)}
+	rdr := bufio.NewReader(r)
+	var mbuf bytes.Buffer
+	for {
+		pfx, err := rdr.Peek(1)
+		if err != nil || pfx[0] != '#' {
+			break
+		}
+		line, err := rdr.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return set, fmt.Errorf("error in reading metadata %s", err)
+		}
+		mbuf.WriteString(strings.TrimPrefix(line, "#"))
+	}
+	if err := yaml.Unmarshal(mbuf.Bytes(), set.Metadata); err != nil {
+		return set, fmt.Errorf("error in decoding metadata %s", err)
+	}
+	scn := bufio.NewScanner(rdr)
+	scn.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	if !scn.Scan() {
+		if err := scn.Err(); err != nil {
+			return set, fmt.Errorf("error in reading header %s", err)
+		}
+
+		return set, fmt.Errorf("error in reading header %s", io.EOF)
+	}
+	header := splitRow(scn.Text())
+	for _, col := range required {
+		if !hasColumn(header, col) {
+			return set, fmt.Errorf("column %s is missing", col)
+		}
+	}
+	for scn.Scan() {
+		if len(strings.TrimSpace(scn.Text())) == 0 {
+			continue
+		}
+		rec := splitRow(scn.Text())
+		if len(rec) > len(header) {
+			return set, fmt.Errorf("mapping of %s has more values than columns", rec[0])
+		}
+		if len(rec) < len(header) {
+			return set, fmt.Errorf("mapping of %s has fewer values than columns", rec[0])
+		}
+		mpg, err := newMapping(header, rec)
+		if err != nil {
+			return set, err
+		}
+		if err := mpg.validate(len(set.Mappings) + 1); err != nil {
+			return set, err
+		}
+		set.Mappings = append(set.Mappings, mpg)
+	}
+	if err := scn.Err(); err != nil {
+		return set, fmt.Errorf("error in reading mapping %s", err)
+	}
+
+	return set, nil
+}
+
+// Write writes the mapping set in SSSOM TSV format. The header has the
+// required columns, the optional ones having any value and then the extra
+// columns sorted by name. The values are joined by tabs without any quoting,
+// so a value with a tab or a line break is an error, as is a mapping with an
+// empty required value.
+func (s *MappingSet) Write(w io.Writer) error {
+	if s.Metadata != nil {
+		if err := writeMetadata(w, s.Metadata); err != nil {
+			return err
+		}
+	}
+	header := s.header()
+	if _, err := io.WriteString(w, strings.Join(header, "\t")+"\n"); err != nil {
+		return fmt.Errorf("error in writing header %s", err)
+	}
+	for i, mpg := range s.Mappings {
+		if err := mpg.validate(i + 1); err != nil {
+			return err
+		}
+		rec := make([]string, 0, len(header))
+		for _, col := range header {
+			val := mpg.value(col)
+			if strings.ContainsAny(val, "\t\r\n") {
+				return fmt.Errorf("value of %s of mapping %s has a tab or line break", col, mpg.SubjectID)
+			}
+			rec = append(rec, val)
+		}
+		if _, err := io.WriteString(w, strings.Join(rec, "\t")+"\n"); err != nil {
+			return fmt.Errorf("error in writing mapping %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *MappingSet) header() []string {
+	used := make(map[string]bool)
+	for _, col := range required {
+		used[col] = true
+	}
+	extra := make([]string, 0)
+	for _, mpg := range s.Mappings {
+		for _, col := range columns {
+			used[col] = used[col] || len(mpg.value(col)) > 0
+		}
+		for col := range mpg.Extra {
+			if !used[col] {
+				used[col] = true
+				extra = append(extra, col)
+			}
+		}
+	}
+	sort.Strings(extra)
+	header := make([]string, 0, len(columns)+len(extra))
+	for _, col := range columns {
+		if used[col] {
+			header = append(header, col)
+		}
+	}
+
+	return append(header, extra...)
+}
+
+func writeMetadata(w io.Writer, mta *Metadata) error {
+	ymt, err := yaml.Marshal(mta)
+	if err != nil {
+		return fmt.Errorf("error in encoding metadata %s", err)
+	}
+	if strings.TrimSpace(string(ymt)) == "{}" {
+		return nil
+	}
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(string(ymt), "\n"), "\n") {
+		if _, err := io.WriteString(w, "#"+line); err != nil {
+			return fmt.Errorf("error in writing metadata %s", err)
+		}
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("error in writing metadata %s", err)
+	}
+
+	return nil
+}
+
+func newMapping(header, rec []string) (*Mapping, error) {
+	mpg := &Mapping{Extra: make(map[string]string)}
+	for i, val := range rec {
+		col := header[i]
+		switch col {
+		case SubjectID:
+			mpg.SubjectID = val
+		case SubjectLabel:
+			mpg.SubjectLabel = val
+		case PredicateID:
+			mpg.PredicateID = val
+		case ObjectID:
+			mpg.ObjectID = val
+		case ObjectLabel:
+			mpg.ObjectLabel = val
+		case MappingJustification:
+			mpg.MappingJustification = val
+		case Confidence:
+			if len(val) == 0 {
+				continue
+			}
+			cnf, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return mpg, fmt.Errorf("error in parsing confidence %s", err)
+			}
+			mpg.Confidence = &cnf
+		case Comment:
+			mpg.Comment = val
+		default:
+			if len(val) > 0 {
+				mpg.Extra[col] = val
+			}
+		}
+	}
+
+	return mpg, nil
+}
+
+// validate checks that the mapping at the given row, counted from one, has
+// all the required values.
+func (m *Mapping) validate(row int) error {
+	for _, col := range required {
+		if len(strings.TrimSpace(m.value(col))) == 0 {
+			return fmt.Errorf("mapping %d has no value for %s", row, col)
+		}
+	}
+
+	return nil
+}
+
+func (m *Mapping) value(col string) string {
+	switch col {
+	case SubjectID:
+		return m.SubjectID
+	case SubjectLabel:
+		return m.SubjectLabel
+	case PredicateID:
+		return m.PredicateID
+	case ObjectID:
+		return m.ObjectID
+	case ObjectLabel:
+		return m.ObjectLabel
+	case MappingJustification:
+		return m.MappingJustification
+	case Confidence:
+		if m.Confidence == nil {
+			return ""
+		}
+
+		return strconv.FormatFloat(*m.Confidence, 'f', -1, 64)
+	case Comment:
+		return m.Comment
+	}
+
+	return m.Extra[col]
+}
+
+func splitRow(line string) []string {
+	return strings.Split(strings.TrimSuffix(line, "\r"), "\t")
+}
+
+func hasColumn(header []string, col string) bool {
+	for _, hdr := range header {
+		if hdr == col {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,206 @@
+package sssom
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/dictyBase/go-obograph/model"
+	"github.com/stretchr/testify/require"
+)
+
+const tsv = `#mapping_set_id: https://example.org/dicty.sssom.tsv
+#license: https://creativecommons.org/licenses/by/4.0/
+#curie_map:
+#  SO: http://purl.obolibrary.org/obo/SO_
+#  EX: https://example.org/
+#creator_id:
+#  - orcid:0000-0001
+subject_id	predicate_id	object_id	mapping_justification	confidence	author_id
+SO:0000704	skos:exactMatch	EX:gene	semapv:ManualMappingCuration	0.9	orcid:0000-0001
+EX:locus	skos:broadMatch	SO:0000704	semapv:ManualMappingCuration		
+SO:0000704	oboInOwl:hasDbXref	EX:g1	semapv:UnspecifiedMatching		
+EX:a	skos:exactMatch	EX:b	semapv:UnspecifiedMatching		
+EX:c	skos:closeMatch	EX:"d"	semapv:ManualMappingCuration	0	
+`
+
+func buildGraph(assert *require.Assertions) graph.OboGraph {
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(
+		filepath.Join(
+			filepath.Dir(dir), "testdata", "so.json",
+		),
+	)
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	grph, err := graph.BuildGraph(rdr)
+	assert.NoError(err, "expect no error from building the graph")
+
+	return grph
+}
+
+func TestReadWrite(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	set, err := Read(strings.NewReader(tsv))
+	assert.NoError(err, "expect no error from reading the mappings")
+	assert.Equal("https://example.org/dicty.sssom.tsv", set.Metadata.MappingSetID)
+	assert.Equal("https://example.org/", set.Metadata.CurieMap["EX"])
+	assert.Contains(set.Metadata.Extra, "creator_id", "expect the other metadata to be kept")
+	assert.Len(set.Mappings, 5)
+	assert.Equal("EX:gene", set.Mappings[0].ObjectID)
+	assert.Equal(0.9, *set.Mappings[0].Confidence)
+	assert.Equal("orcid:0000-0001", set.Mappings[0].Extra["author_id"])
+	assert.Nil(set.Mappings[1].Confidence, "expect no confidence")
+	assert.NotNil(set.Mappings[4].Confidence, "expect a confidence of zero")
+	assert.Zero(*set.Mappings[4].Confidence, "expect a confidence of zero")
+	assert.Equal(`EX:"d"`, set.Mappings[4].ObjectID, "expect the quotes to be kept")
+	assert.Empty(set.Prefixes(), "expect every prefix to be expandable")
+
+	var buf bytes.Buffer
+	assert.NoError(set.Write(&buf), "expect no error from writing the mappings")
+	assert.True(strings.HasPrefix(buf.String(), "#"), "expect the metadata block first")
+	assert.Contains(buf.String(), "EX:c\tskos:closeMatch\tEX:\"d\"\tsemapv:ManualMappingCuration\t0\t\n")
+	assert.Contains(
+		buf.String(),
+		"subject_id\tpredicate_id\tobject_id\tmapping_justification\tconfidence\tauthor_id\n",
+	)
+	rset, err := Read(&buf)
+	assert.NoError(err, "expect no error from reading the written mappings")
+	assert.Equal(set.Metadata, rset.Metadata)
+	assert.Equal(set.Mappings, rset.Mappings)
+
+	_, err = Read(strings.NewReader("subject_id\tobject_id\nSO:1\tEX:1\n"))
+	assert.Error(err, "expect error for missing predicate column")
+	_, err = Read(strings.NewReader("subject_id\tpredicate_id\tobject_id\nSO:1\tskos:exactMatch\tEX:1\n"))
+	assert.Error(err, "expect error for missing justification column")
+	_, err = Read(strings.NewReader(
+		"subject_id\tpredicate_id\tobject_id\tmapping_justification\tconfidence\n" +
+			"SO:1\tskos:exactMatch\tEX:1\tsemapv:UnspecifiedMatching\thigh\n",
+	))
+	assert.Error(err, "expect error for invalid confidence")
+	buf.Reset()
+	tab := &MappingSet{Mappings: []*Mapping{{
+		SubjectID: "SO:1", PredicateID: "skos:exactMatch", ObjectID: "EX:1",
+		MappingJustification: UnspecifiedMatching, Comment: "a\tb",
+	}}}
+	assert.Error(tab.Write(&buf), "expect error for a value with a tab")
+}
+
+func TestReadWriteRequired(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	header := "subject_id\tpredicate_id\tobject_id\tmapping_justification\tcomment\n"
+	for name, row := range map[string]string{
+		"subject":       "\tskos:exactMatch\tEX:1\tsemapv:UnspecifiedMatching\t\n",
+		"predicate":     "SO:1\t\tEX:1\tsemapv:UnspecifiedMatching\t\n",
+		"object":        "SO:1\tskos:exactMatch\t \tsemapv:UnspecifiedMatching\t\n",
+		"justification": "SO:1\tskos:exactMatch\tEX:1\t\t\n",
+		"short row":     "SO:1\tskos:exactMatch\tEX:1\tsemapv:UnspecifiedMatching\n",
+	} {
+		_, err := Read(strings.NewReader(header + row))
+		assert.Errorf(err, "expect error from reading a mapping without %s", name)
+	}
+	for name, mpg := range map[string]*Mapping{
+		"subject": {
+			PredicateID: "skos:exactMatch", ObjectID: "EX:1",
+			MappingJustification: UnspecifiedMatching,
+		},
+		"predicate": {
+			SubjectID: "SO:1", ObjectID: "EX:1",
+			MappingJustification: UnspecifiedMatching,
+		},
+		"object": {
+			SubjectID: "SO:1", PredicateID: "skos:exactMatch", ObjectID: " ",
+			MappingJustification: UnspecifiedMatching,
+		},
+		"justification": {
+			SubjectID: "SO:1", PredicateID: "skos:exactMatch", ObjectID: "EX:1",
+		},
+	} {
+		var buf bytes.Buffer
+		set := &MappingSet{Mappings: []*Mapping{mpg}}
+		assert.Errorf(set.Write(&buf), "expect error from writing a mapping without %s", name)
+	}
+}
+
+func TestFromGraph(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	set := FromGraph(grph, &Options{CurieMap: map[string]string{"RNAMOD": "https://example.org/rnamod/"}})
+	assert.Equal(grph.IRI(), set.Metadata.MappingSetID)
+	var rnamod *Mapping
+	for _, mpg := range set.Mappings {
+		assert.Equal(DbXref, mpg.PredicateID)
+		assert.Equal(UnspecifiedMatching, mpg.MappingJustification)
+		assert.False(strings.HasPrefix(mpg.ObjectID, "http"), "expect no url xref")
+		if mpg.ObjectID == "RNAMOD:001" {
+			rnamod = mpg
+		}
+	}
+	assert.NotNil(rnamod, "expect a mapping from the xref")
+	assert.Equal("SO:0001295", rnamod.SubjectID)
+	assert.Equal("one_methyladenosine", rnamod.SubjectLabel)
+	assert.Equal("http://purl.obolibrary.org/obo/SO_", set.Metadata.CurieMap["SO"])
+	assert.Equal("https://example.org/rnamod/", set.Metadata.CurieMap["RNAMOD"])
+	assert.Equal(builtinPrefixes["semapv"], set.Metadata.CurieMap["semapv"])
+	assert.NotContains(set.Metadata.CurieMap, "MOD", "expect no expansion for unknown prefixes")
+	assert.Contains(set.Prefixes(), "MOD")
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	grph := buildGraph(assert)
+	set, err := Read(strings.NewReader(tsv))
+	assert.NoError(err, "expect no error from reading the mappings")
+	res := Apply(grph, set)
+	assert.Len(res.Applied, 3)
+	assert.Len(res.Skipped, 2)
+	assert.Equal("EX:a", res.Skipped[0].SubjectID)
+	assert.Equal("EX:c", res.Skipped[1].SubjectID)
+	gene := grph.GetTerm("SO_0000704")
+	assert.Contains(gene.Meta().XrefsValues(), "EX:g1", "expect the xref mapping as xref")
+	props := make(map[string]string)
+	for _, prop := range gene.Meta().BasicPropertyValues() {
+		props[prop.Value()] = prop.Pred()
+	}
+	assert.Equal(model.ExactMatchIRI, props["EX:gene"])
+	assert.Equal(model.NarrowMatchIRI, props["EX:locus"], "expect the inverse predicate on the object")
+	assert.NotEmpty(grph.Children("SO_0000704"), "expect the relationships to be kept")
+
+	cnt := len(gene.Meta().BasicPropertyValues())
+	Apply(grph, set)
+	assert.Len(grph.GetTerm("SO_0000704").Meta().BasicPropertyValues(), cnt, "expect no repeated mapping")
+	mset := FromGraph(grph, nil)
+	var found bool
+	for _, mpg := range mset.Mappings {
+		found = found || (mpg.SubjectID == "SO:0000704" && mpg.PredicateID == "skos:exactMatch" && mpg.ObjectID == "EX:gene")
+	}
+	assert.True(found, "expect the applied mapping in the generated set")
+
+	res = Apply(grph, &MappingSet{Mappings: []*Mapping{
+		{
+			SubjectID: "EX:part", PredicateID: "BFO:0000050", ObjectID: "SO:0000704",
+			MappingJustification: UnspecifiedMatching,
+		},
+		{
+			SubjectID: "EX:same", PredicateID: "skos:closeMatch", ObjectID: "SO:0000704",
+			MappingJustification: UnspecifiedMatching,
+		},
+	}})
+	assert.Len(res.Skipped, 1, "expect the mapping without inverse to be skipped")
+	assert.Equal("EX:part", res.Skipped[0].SubjectID)
+	assert.Len(res.Applied, 1, "expect the symmetric mapping on the object")
+	props = make(map[string]string)
+	for _, prop := range grph.GetTerm("SO_0000704").Meta().BasicPropertyValues() {
+		props[prop.Value()] = prop.Pred()
+	}
+	assert.Equal(model.CloseMatchIRI, props["EX:same"], "expect the symmetric predicate on the object")
+	assert.NotContains(props, "EX:part", "expect no mapping without inverse")
+}
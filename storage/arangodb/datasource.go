@@ -165,13 +165,9 @@ func (a *arangoSource) SaveTerms(grph graph.OboGraph) (int, error) {
 }
 
 func (a *arangoSource) SaveRelationships(g graph.OboGraph) (int, error) {
-	dbrs := make([]*dbRelationship, 0)
-	for _, r := range g.Relationships() {
-		dbrel, err := a.todbRelationhip(r)
-		if err != nil {
-			return 0, err
-		}
-		dbrs = append(dbrs, dbrel)
+	dbrs, err := a.todbRelationships(g)
+	if err != nil {
+		return 0, err
 	}
 	stat, err := a.relc.ImportDocuments(
 		context.Background(),
@@ -277,9 +273,11 @@ func (a *arangoSource) todbGraphMeta(grph graph.OboGraph) *dbGraphMeta {
 	dpg := make([]*dbGraphProps, 0)
 	for _, p := range grph.Meta().BasicPropertyValues() {
 		dpg = append(dpg, &dbGraphProps{
-			Pred:  p.Pred(),
-			Value: p.Value(),
-			Curie: curieMap[p.Pred()],
+			Pred:    p.Pred(),
+			Value:   p.Value(),
+			Curie:   curieMap[p.Pred()],
+			ValType: p.ValType(),
+			Lang:    p.Lang(),
 		})
 	}
 
@@ -342,49 +340,64 @@ func (a *arangoSource) todbTerm(idn string, trm graph.Term) *dbTerm {
 	return dbt
 }
 
-func (a *arangoSource) todbRelationhip(rgp graph.Relationship) (*dbRelationship, error) {
-	oMap := make(map[graph.NodeID]string)
-	dbr := &dbRelationship{}
-	if v, ok := oMap[rgp.Object()]; ok {
-		dbr.From = v
-	} else {
-		id, err := a.getDocID(rgp.Object())
-		if err != nil {
-			return dbr, err
-		}
-		oMap[rgp.Object()] = id
-		dbr.From = id
+// todbRelationships converts the relationships of the graph, their terms
+// are looked up among the terms of the graph only, as the same term, such as
+// a property or is_a, can be stored for several graphs.
+func (a *arangoSource) todbRelationships(grph graph.OboGraph) ([]*dbRelationship, error) {
+	dbrs := make([]*dbRelationship, 0)
+	idg, err := a.graphDocID(grph)
+	if err != nil {
+		return dbrs, err
 	}
-	if v, ok := oMap[rgp.Subject()]; ok {
-		dbr.To = v
-	} else {
-		id, err := a.getDocID(rgp.Subject())
+	docIDs := make(map[graph.NodeID]string)
+	for _, rel := range grph.Relationships() {
+		dbrel, err := a.todbRelationhip(idg, rel, docIDs)
 		if err != nil {
-			return dbr, err
+			return dbrs, err
 		}
-		oMap[rgp.Subject()] = id
-		dbr.To = id
+		dbrs = append(dbrs, dbrel)
 	}
-	if v, ok := oMap[rgp.Predicate()]; ok {
-		dbr.Predicate = v
-	} else {
-		id, err := a.getDocID(rgp.Predicate())
+
+	return dbrs, nil
+}
+
+func (a *arangoSource) todbRelationhip(
+	idg string,
+	rgp graph.Relationship,
+	docIDs map[graph.NodeID]string,
+) (*dbRelationship, error) {
+	dbr := &dbRelationship{}
+	for _, fld := range []struct {
+		nid graph.NodeID
+		val *string
+	}{
+		{rgp.Object(), &dbr.From},
+		{rgp.Subject(), &dbr.To},
+		{rgp.Predicate(), &dbr.Predicate},
+	} {
+		if id, ok := docIDs[fld.nid]; ok {
+			*fld.val = id
+
+			continue
+		}
+		id, err := a.getDocID(idg, fld.nid)
 		if err != nil {
 			return dbr, err
 		}
-		oMap[rgp.Predicate()] = id
-		dbr.Predicate = id
+		docIDs[fld.nid] = id
+		*fld.val = id
 	}
 
 	return dbr, nil
 }
 
-func (a *arangoSource) getDocID(nid graph.NodeID) (string, error) {
+func (a *arangoSource) getDocID(idg string, nid graph.NodeID) (string, error) {
 	return a.graphDocQuery(
-		getid,
+		gettermid,
 		map[string]interface{}{
 			"@db_collection": a.termc.Name(),
 			"db_id":          string(nid),
+			"graph_id":       idg,
 		})
 }
 
@@ -456,13 +469,9 @@ func (a *arangoSource) loadRelationsinTemp(grph graph.OboGraph) (*arangoCollecti
 	if err != nil {
 		return coll, fnc, err
 	}
-	dbrs := make([]*dbRelationship, 0)
-	for _, r := range grph.Relationships() {
-		dbrel, err := a.todbRelationhip(r)
-		if err != nil {
-			return coll, fnc, err
-		}
-		dbrs = append(dbrs, dbrel)
+	dbrs, err := a.todbRelationships(grph)
+	if err != nil {
+		return coll, fnc, err
 	}
 	_, err = tmpColl.ImportDocuments(
 		context.Background(),
@@ -518,9 +527,11 @@ func termMetaProperties(trm graph.Term) []*dbGraphProps {
 	}
 	for _, prop := range trm.Meta().BasicPropertyValues() {
 		dps = append(dps, &dbGraphProps{
-			Pred:  prop.Pred(),
-			Value: prop.Value(),
-			Curie: curieMap[prop.Pred()],
+			Pred:    prop.Pred(),
+			Value:   prop.Value(),
+			Curie:   curieMap[prop.Pred()],
+			ValType: prop.ValType(),
+			Lang:    prop.Lang(),
 		})
 	}
 
@@ -11,13 +11,17 @@ import (
 )
 
 func oboReader(assert *require.Assertions) *os.File {
+	return fixtureReader(assert, "dicty_phenotypes.json")
+}
+
+func fixtureReader(assert *require.Assertions, name string) *os.File {
 	dir, err := os.Getwd()
 	if err != nil {
 		assert.NoErrorf(err, "unable to get current dir %s", err)
 	}
 	fho, err := os.Open(
 		filepath.Join(
-			filepath.Dir(dir), "testdata", "dicty_phenotypes.json",
+			filepath.Dir(dir), "testdata", name,
 		),
 	)
 	assert.NoErrorf(err, "unable to open file %s", err)
@@ -90,3 +94,39 @@ func TestLoadOboJSONFromDataSource(t *testing.T) {
 	assert.Equal(info2.TermStats.Updated, 1271, "should have updated 1271 term")
 	assert.Equal(info2.TermStats.Deleted, 0, "should have not deleted any term")
 }
+
+func TestLoadOboJSONByNamespace(t *testing.T) {
+	t.Parallel()
+	assert, ta, dsr := setUp(t)
+	r := oboReader(assert)
+	defer tearDown(assert, ta)
+	defer r.Close()
+	infos, err := storage.LoadOboJSONByNamespace(r, dsr)
+	assert.NoErrorf(err, "expect no error from loading, received %s", err)
+	assert.Len(infos, 1, "expect a single namespace")
+	info, ok := infos["Dicty Phenotypes"]
+	assert.True(ok, "expect the namespace to be loaded")
+	assert.True(info.IsCreated, "expect the namespace to be created")
+	assert.Equal(info.RelationStats, 1143, "should load 1143 relationships")
+	assert.Equal(info.TermStats.Created, 1271, "should load 1271 terms")
+}
+
+func TestLoadOboJSONByNamespaceShared(t *testing.T) {
+	t.Parallel()
+	assert, ta, dsr := setUp(t)
+	r := fixtureReader(assert, "namespaces.json")
+	defer tearDown(assert, ta)
+	defer r.Close()
+	infos, err := storage.LoadOboJSONByNamespace(r, dsr)
+	assert.NoErrorf(err, "expect no error from loading, received %s", err)
+	assert.Len(infos, 2, "expect two namespaces")
+	for nsp, stats := range map[string][2]int{
+		"alpha": {9, 2},
+		"beta":  {10, 3},
+	} {
+		info, ok := infos[nsp]
+		assert.Truef(ok, "expect namespace %s to be loaded", nsp)
+		assert.Equalf(stats[0], info.TermStats.Created, "should load the terms of %s", nsp)
+		assert.Equalf(stats[1], info.RelationStats, "should load the relationships of %s", nsp)
+	}
+}
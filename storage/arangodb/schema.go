@@ -37,9 +37,11 @@ type dbGraphMeta struct {
 }
 
 type dbGraphProps struct {
-	Pred  string `json:"pred,omitempty"`
-	Value string `json:"value,omitempty"`
-	Curie string `json:"curie,omitempty"`
+	Pred    string `json:"pred,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Curie   string `json:"curie,omitempty"`
+	ValType string `json:"val_type,omitempty"`
+	Lang    string `json:"lang,omitempty"`
 }
 
 type dbTerm struct {
@@ -11,6 +11,12 @@ const (
 			FILTER d.id == @db_id
 			RETURN d._id
 	`
+	gettermid = `
+		FOR d IN @@db_collection
+			FILTER d.id == @db_id
+			FILTER d.graph_id == @graph_id
+			RETURN d._id
+	`
 	getd = `
 		FOR d IN @@graph_collection
 			FILTER d.id == @graph_id
@@ -116,6 +122,7 @@ const (
 					FOR z IN @@temp_collection
 		                FOR cvtn IN @@term_collection
 		                    FILTER n == cvtn.id
+		                    FILTER cvtn.graph_id == c._id
 		                    FILTER cvtn._id == z._to
 		                    FILTER cvt._id == z._from
 		                    INSERT {
@@ -24,6 +24,34 @@ func LoadOboJSONFromDataSource(r io.Reader, dsr DataSource) (*UploadInformation,
 	if err != nil {
 		return info, fmt.Errorf("error in building graph %s", err)
 	}
+
+	return persistOboGraph(dsr, grph)
+}
+
+// LoadOboJSONByNamespace loads obojson from a given reader and splits it
+// into a graph per namespace, every graph is stored on its own with an id
+// suffixed by its namespace. The shared terms are stored once per graph. The
+// upload information is keyed by namespace, the relationships across
+// namespaces are not stored.
+func LoadOboJSONByNamespace(r io.Reader, dsr DataSource) (map[string]*UploadInformation, error) {
+	infos := make(map[string]*UploadInformation)
+	grph, err := graph.BuildGraph(r)
+	if err != nil {
+		return infos, fmt.Errorf("error in building graph %s", err)
+	}
+	grphs, _ := graph.SplitByNamespace(grph)
+	for _, ngrph := range grphs {
+		info, err := persistOboGraph(dsr, ngrph)
+		if err != nil {
+			return infos, fmt.Errorf("error in loading graph %s %s", ngrph.ID(), err)
+		}
+		infos[ngrph.Meta().Namespace()] = info
+	}
+
+	return infos, nil
+}
+
+func persistOboGraph(dsr DataSource, grph graph.OboGraph) (*UploadInformation, error) {
 	if dsr.ExistsOboGraph(grph) {
 		return persistExistOboGraph(dsr, grph)
 	}
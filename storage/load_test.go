@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dictyBase/go-obograph/graph"
+	"github.com/stretchr/testify/require"
+)
+
+// termDoc is a stored term, scoped by the id of its graph.
+type termDoc struct {
+	graphID string
+	id      graph.NodeID
+}
+
+// memSource is a DataSource that keeps the terms of every graph apart, like
+// a database scoping them by graph id, and resolves the terms of a
+// relationship within the graph being saved.
+type memSource struct {
+	terms map[string]map[graph.NodeID]termDoc
+	rels  map[string][][3]termDoc
+}
+
+func newMemSource() *memSource {
+	return &memSource{
+		terms: make(map[string]map[graph.NodeID]termDoc),
+		rels:  make(map[string][][3]termDoc),
+	}
+}
+
+func (m *memSource) SaveOboGraphInfo(grph graph.OboGraph) error {
+	m.terms[grph.ID()] = make(map[graph.NodeID]termDoc)
+
+	return nil
+}
+
+func (m *memSource) UpdateOboGraphInfo(grph graph.OboGraph) error {
+	return nil
+}
+
+func (m *memSource) ExistsOboGraph(grph graph.OboGraph) bool {
+	_, ok := m.terms[grph.ID()]
+
+	return ok
+}
+
+func (m *memSource) SaveTerms(grph graph.OboGraph) (int, error) {
+	cnt := 0
+	grph.EachTerm(func(trm graph.Term) bool {
+		m.terms[grph.ID()][trm.ID()] = termDoc{graphID: grph.ID(), id: trm.ID()}
+		cnt++
+
+		return true
+	})
+
+	return cnt, nil
+}
+
+func (m *memSource) UpdateTerms(grph graph.OboGraph) (int, error) {
+	return m.SaveTerms(grph)
+}
+
+func (m *memSource) SaveOrUpdateTerms(grph graph.OboGraph) (*Stats, error) {
+	cnt, err := m.SaveTerms(grph)
+
+	return &Stats{Updated: cnt}, err
+}
+
+func (m *memSource) SaveRelationships(grph graph.OboGraph) (int, error) {
+	var err error
+	cnt := 0
+	grph.EachRelationship(func(rel graph.Relationship) bool {
+		key := [3]termDoc{}
+		for i, idn := range []graph.NodeID{rel.Subject(), rel.Predicate(), rel.Object()} {
+			doc, ok := m.terms[grph.ID()][idn]
+			if !ok {
+				err = fmt.Errorf("term %s is not in graph %s", idn, grph.ID())
+
+				return false
+			}
+			key[i] = doc
+		}
+		m.rels[grph.ID()] = append(m.rels[grph.ID()], key)
+		cnt++
+
+		return true
+	})
+
+	return cnt, err
+}
+
+func (m *memSource) SaveNewRelationships(grph graph.OboGraph) (int, error) {
+	return m.SaveRelationships(grph)
+}
+
+func TestLoadOboJSONByNamespaceShared(t *testing.T) {
+	t.Parallel()
+	assert := require.New(t)
+	dir, err := os.Getwd()
+	assert.NoErrorf(err, "unable to get current dir %s", err)
+	rdr, err := os.Open(filepath.Join(dir, "testdata", "namespaces.json"))
+	assert.NoErrorf(err, "error in opening file %s", err)
+	defer rdr.Close()
+	dsr := newMemSource()
+	infos, err := LoadOboJSONByNamespace(rdr, dsr)
+	assert.NoErrorf(err, "expect no error from loading, received %s", err)
+	assert.Len(infos, 2, "expect two namespaces")
+	assert.Len(dsr.terms, 2, "expect a graph per namespace")
+	shared := graph.NodeID("BFO_0000050")
+	for gid, terms := range dsr.terms {
+		assert.Containsf(terms, shared, "expect the shared term in graph %s", gid)
+		var found bool
+		for _, rel := range dsr.rels[gid] {
+			found = found || rel[2] == terms[shared]
+			for _, doc := range rel {
+				assert.Equalf(
+					gid, doc.graphID,
+					"expect relationship of graph %s to resolve to its own terms", gid,
+				)
+			}
+		}
+		assert.Truef(found, "expect a relationship to the shared term in graph %s", gid)
+	}
+}
@@ -1,5 +1,8 @@
 // Package storage provides type definition for managing OBO
-// graphs in a persistent storage
+// graphs in a persistent storage. A graph loaded by namespace is stored as a
+// graph per namespace, the terms shared by the namespaces, such as the
+// properties, are duplicated in every graph and the relationships of a graph
+// refer to its own copies of them.
 package storage
 
 import (